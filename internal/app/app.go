@@ -4,12 +4,18 @@ import (
 	"context"
 	"errors"
 	"estimate/internal/config"
+	"estimate/internal/metrics"
+	"estimate/internal/notifier"
 	"estimate/internal/service"
 	"estimate/internal/storage"
+	"estimate/internal/transport/grpc"
 	"estimate/internal/transport/rest"
 	"estimate/internal/transport/rest/handler"
+	"estimate/internal/transport/rest/middleware"
 	loggerpkg "estimate/pkg/logger"
 	"estimate/pkg/postgres"
+	"estimate/pkg/ratelimit"
+	"estimate/pkg/retry"
 	"github.com/alejandro-carstens/gocache"
 	"github.com/alejandro-carstens/gocache/encoder"
 	"github.com/redis/go-redis/v9"
@@ -17,6 +23,7 @@ import (
 	"net/http"
 	"os/signal"
 	"syscall"
+	"time"
 )
 
 type App struct {
@@ -70,35 +77,121 @@ func (app *App) Run() {
 
 	estimateCache := cache.Tags("estimate")
 
+	recorder := metrics.New()
+
 	websiteStorage := storage.NewWebsiteStorage(pgClient)
-	websiteService := service.NewWebsiteService(websiteStorage, estimateCache)
+	checkStorage := storage.NewCheckStorage(pgClient)
+	incidentStorage := storage.NewIncidentStorage(pgClient)
+	channelStorage := storage.NewChannelStorage(pgClient)
+	notificationService := service.NewNotificationService(
+		channelStorage,
+		notifier.New(retry.Config{
+			Attempts:        app.conf.Notify.RetryAttempts,
+			InitialInterval: app.conf.Notify.RetryInterval,
+			MaxInterval:     app.conf.Notify.RetryMaxInterval,
+		}),
+	)
+	resultService := service.NewResultService()
+	websiteService := service.NewWebsiteService(
+		websiteStorage,
+		checkStorage,
+		incidentStorage,
+		notificationService,
+		resultService,
+		estimateCache,
+		service.Thresholds{
+			Failure: app.conf.State.FailureThreshold,
+			Success: app.conf.State.SuccessThreshold,
+		},
+		time.Duration(app.conf.Notify.CertExpiryDays)*24*time.Hour,
+		recorder,
+	)
+	checkService := service.NewCheckService(checkStorage, websiteStorage)
+	incidentService := service.NewIncidentService(incidentStorage, websiteStorage)
 
 	logger.Info("starting estimation service")
 	go func() {
-		err = websiteService.Watch(ctx, app.conf.WatchPeriod)
+		err := websiteService.Watch(ctx, app.conf.WatchPeriod)
 		if err != nil && !errors.Is(err, context.Canceled) {
 			logger.Fatal("failed to start estimation service", zap.Error(err))
 		}
 	}()
 
-	metricsStorage := storage.NewMetricsStorage(redisClient)
-	metricsService := service.NewMetricsService(metricsStorage)
+	apiKeyStorage := storage.NewAPIKeyStorage(pgClient)
+	apiKeyService := service.NewAPIKeyService(apiKeyStorage)
+
+	metricsStorage := storage.NewMetricsStorage(redisClient, app.conf.Metrics.Retention)
+	metricsService := service.NewMetricsService(metricsStorage, app.conf.Metrics.Retention)
+
+	limiter := ratelimit.New(redisClient)
+	apiLimiter := middleware.NewRateLimiter(
+		limiter,
+		"api",
+		"X-RateLimit",
+		ratelimit.Limit{Requests: app.conf.RateLimit.IPRequests, Window: app.conf.RateLimit.Window},
+		ratelimit.Limit{Requests: app.conf.RateLimit.KeyRequests, Window: app.conf.RateLimit.Window},
+	)
+	liveLimiter := middleware.NewRateLimiter(
+		limiter,
+		"live",
+		"X-RateLimit-Live",
+		ratelimit.Limit{Requests: app.conf.RateLimit.LiveIPRequests, Window: app.conf.RateLimit.Window},
+		ratelimit.Limit{Requests: app.conf.RateLimit.LiveKeyRequests, Window: app.conf.RateLimit.Window},
+	)
+
+	authFailures := ratelimit.NewFailures(
+		limiter,
+		ratelimit.Limit{Requests: app.conf.RateLimit.AuthFailures, Window: app.conf.RateLimit.Window},
+	)
 
-	estimateHandler := handler.NewEstimateHandler(websiteService, estimateCache)
-	adminHandler := handler.NewAdminHandler(metricsService)
+	estimateHandler := handler.NewEstimateHandler(websiteService, checkService, resultService, estimateCache, liveLimiter)
+	certificateHandler := handler.NewCertificateHandler(checkService, estimateCache)
+	incidentHandler := handler.NewIncidentHandler(incidentService, estimateCache)
+	websiteHandler := handler.NewWebsiteHandler(websiteService, estimateCache)
+	statusHandler := handler.NewStatusHandler(websiteService, estimateCache, app.conf.Status.CacheTTL)
+	adminHandler := handler.NewAdminHandler(metricsService, websiteService, notificationService, apiKeyService)
 
 	server := rest.New(
 		app.conf.Server,
-		redisClient,
+		metricsService,
+		apiKeyService,
+		apiLimiter,
+		authFailures,
+		recorder,
 		logger,
 	).Handle(
 		estimateHandler,
+		certificateHandler,
+		incidentHandler,
+		websiteHandler,
+		statusHandler,
 		adminHandler,
 	)
 
+	grpcServer := grpc.New(
+		app.conf.GRPC,
+		app.conf.Server.PublicAPI,
+		websiteService,
+		resultService,
+		apiKeyService,
+		limiter,
+		authFailures,
+		ratelimit.Limit{Requests: app.conf.RateLimit.LiveIPRequests, Window: app.conf.RateLimit.Window},
+		ratelimit.Limit{Requests: app.conf.RateLimit.LiveKeyRequests, Window: app.conf.RateLimit.Window},
+		logger,
+	)
+
+	logger.Info("starting grpc service")
+	go func() {
+		err := grpcServer.Listen()
+		if err != nil {
+			logger.Fatal("failed to start grpc service", zap.Error(err))
+		}
+	}()
+
 	logger.Info("starting web service")
 	go func() {
-		err = server.Listen()
+		err := server.Listen()
 		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			logger.Fatal("failed to start web service", zap.Error(err))
 		}
@@ -108,6 +201,12 @@ func (app *App) Run() {
 
 	logger.Info("stopping app")
 
+	// открытые потоки результатов не дают серверам завершиться
+	resultService.Close()
+
+	logger.Info("shutting down grpc service")
+	grpcServer.Shutdown()
+
 	logger.Info("shutting down web service")
 	err = server.Shutdown()
 	if err != nil {
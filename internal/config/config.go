@@ -8,20 +8,42 @@ import (
 
 type Config struct {
 	Server      Server
+	GRPC        GRPC
+	RateLimit   RateLimit
 	Postgres    Postgres
 	Redis       Redis
+	State       State
+	Notify      Notify
+	Metrics     Metrics
+	Status      Status
 	WatchPeriod time.Duration `env:"WATCH_PERIOD" env-default:"5m"`
 	LogLevel    string        `env:"LOG_LEVEL"`
 }
 
 type Server struct {
-	Addr  string `env:"SERVER_ADDR"`
-	Admin struct {
+	Addr      string `env:"SERVER_ADDR"`
+	PublicAPI bool   `env:"SERVER_PUBLIC_API" env-default:"true"`
+	Admin     struct {
 		Username string `env:"SERVER_ADMIN_USERNAME" env-default:"admin"`
 		Password string `env:"SERVER_ADMIN_PASSWORD" env-default:"admin"`
 	}
 }
 
+type GRPC struct {
+	Addr string `env:"GRPC_ADDR" env-default:":9090"`
+}
+
+// RateLimit лимиты запросов за окно Window, Live - для запросов, вызывающих проверку неизвестного сайта,
+// AuthFailures - неудачных попыток аутентификации с одного IP адреса
+type RateLimit struct {
+	Window          time.Duration `env:"RATE_LIMIT_WINDOW" env-default:"1m"`
+	IPRequests      int           `env:"RATE_LIMIT_IP_REQUESTS" env-default:"60"`
+	KeyRequests     int           `env:"RATE_LIMIT_KEY_REQUESTS" env-default:"600"`
+	LiveIPRequests  int           `env:"RATE_LIMIT_LIVE_IP_REQUESTS" env-default:"5"`
+	LiveKeyRequests int           `env:"RATE_LIMIT_LIVE_KEY_REQUESTS" env-default:"30"`
+	AuthFailures    int           `env:"RATE_LIMIT_AUTH_FAILURES" env-default:"10"`
+}
+
 type Postgres struct {
 	Host     string `env:"POSTGRES_HOST"`
 	Port     string `env:"POSTGRES_PORT"`
@@ -30,6 +52,27 @@ type Postgres struct {
 	DB       string `env:"POSTGRES_DB"`
 }
 
+type State struct {
+	FailureThreshold int `env:"STATE_FAILURE_THRESHOLD" env-default:"3"`
+	SuccessThreshold int `env:"STATE_SUCCESS_THRESHOLD" env-default:"2"`
+}
+
+type Notify struct {
+	RetryAttempts    int           `env:"NOTIFY_RETRY_ATTEMPTS" env-default:"5"`
+	RetryInterval    time.Duration `env:"NOTIFY_RETRY_INTERVAL" env-default:"1s"`
+	RetryMaxInterval time.Duration `env:"NOTIFY_RETRY_MAX_INTERVAL" env-default:"1m"`
+	CertExpiryDays   int           `env:"NOTIFY_CERT_EXPIRY_DAYS" env-default:"14"`
+}
+
+type Metrics struct {
+	Retention time.Duration `env:"METRICS_RETENTION" env-default:"168h"`
+}
+
+// Status настройки HTML страницы статуса, CacheTTL - время жизни страницы в кеше и в Cache-Control
+type Status struct {
+	CacheTTL time.Duration `env:"STATUS_CACHE_TTL" env-default:"30s"`
+}
+
 type Redis struct {
 	Addr string `env:"REDIS_ADDR"`
 }
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"time"
+)
+
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func (request CreateAPIKeyRequest) Validate() error {
+	if request.Name == "" {
+		return apperror.BadRequest.WithMessage("name is required")
+	}
+
+	if len(request.Scopes) == 0 {
+		return apperror.BadRequest.WithMessage("at least one scope is required")
+	}
+
+	for _, scope := range request.Scopes {
+		if !knownScope(scope) {
+			return apperror.BadRequest.WithMessage("unknown scope " + scope)
+		}
+	}
+
+	return nil
+}
+
+type APIKeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+func NewAPIKeyResponse(key entity.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// CreateAPIKeyResponse содержит ключ в открытом виде, он возвращается только при создании
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+func knownScope(scope string) bool {
+	for _, s := range entity.Scopes {
+		if string(s) == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"github.com/goware/urlx"
+	"time"
+)
+
+const (
+	DefaultExpiringDays = 30
+	MaxExpiringDays     = 365
+)
+
+type GetCertificateRequest struct {
+	URL string `query:"url"`
+}
+
+func (request GetCertificateRequest) Validate() error {
+	_, err := urlx.Parse(request.URL)
+	if err != nil {
+		return apperror.BadRequest.WithMessage("invalid url")
+	}
+
+	return nil
+}
+
+type GetCertificateResponse struct {
+	URL       string     `json:"url"`
+	CheckedAt time.Time  `json:"checked_at"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	DaysLeft  *int       `json:"days_left"`
+	Issuer    string     `json:"issuer"`
+	SANs      []string   `json:"sans"`
+	Valid     bool       `json:"valid"`
+	Error     string     `json:"error,omitempty"`
+}
+
+func NewGetCertificateResponse(url string, check entity.Check) GetCertificateResponse {
+	response := GetCertificateResponse{
+		URL:       url,
+		CheckedAt: check.CheckedAt,
+		ExpiresAt: check.ExpiresAt,
+		Issuer:    check.Issuer,
+		SANs:      check.SANs,
+		Valid:     check.Error == "",
+		Error:     check.Error,
+	}
+	if check.ExpiresAt != nil {
+		daysLeft := DaysLeft(*check.ExpiresAt)
+		response.DaysLeft = &daysLeft
+	}
+
+	return response
+}
+
+type GetExpiringCertificatesRequest struct {
+	Days int `query:"days"`
+}
+
+func (request GetExpiringCertificatesRequest) Validate() error {
+	if request.Days < 0 || request.Days > MaxExpiringDays {
+		return apperror.BadRequest.WithMessage("days must be between 0 and 365")
+	}
+
+	return nil
+}
+
+type ExpiringCertificateResponse struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	DaysLeft  int       `json:"days_left"`
+}
+
+// DaysLeft количество полных дней до истечения сертификата, отрицательное для истекших
+func DaysLeft(expiresAt time.Time) int {
+	return int(time.Until(expiresAt).Hours() / 24)
+}
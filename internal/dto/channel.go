@@ -0,0 +1,319 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"net/mail"
+	"net/url"
+	"time"
+)
+
+// secretMask заменяет секрет канала в ответах
+const secretMask = "********"
+
+type WebhookConfig struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (config WebhookConfig) Validate() error {
+	u, err := url.ParseRequestURI(config.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return apperror.BadRequest.WithMessage("invalid webhook url")
+	}
+
+	return nil
+}
+
+type EmailConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Security string   `json:"security"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+func (config EmailConfig) Validate() error {
+	return PatchEmailConfig{
+		Host:     &config.Host,
+		Port:     &config.Port,
+		Security: &config.Security,
+		From:     &config.From,
+		To:       &config.To,
+	}.Validate()
+}
+
+func (config EmailConfig) entityConfig() *entity.EmailConfig {
+	return &entity.EmailConfig{
+		Host:     config.Host,
+		Port:     config.Port,
+		Security: config.Security,
+		Username: config.Username,
+		Password: config.Password,
+		From:     config.From,
+		To:       config.To,
+	}
+}
+
+// PatchEmailConfig изменяет настройки email канала по отдельным полям
+type PatchEmailConfig struct {
+	Host     *string   `json:"host"`
+	Port     *int      `json:"port"`
+	Security *string   `json:"security"`
+	Username *string   `json:"username"`
+	Password *string   `json:"password"`
+	From     *string   `json:"from"`
+	To       *[]string `json:"to"`
+}
+
+func (config PatchEmailConfig) Validate() error {
+	if config.Host != nil && *config.Host == "" {
+		return apperror.BadRequest.WithMessage("smtp host is required")
+	}
+
+	if config.Port != nil && (*config.Port < 1 || *config.Port > 65535) {
+		return apperror.BadRequest.WithMessage("invalid smtp port")
+	}
+
+	if config.Security != nil {
+		switch *config.Security {
+		case entity.EmailSecurityStartTLS, entity.EmailSecurityTLS, entity.EmailSecurityNone:
+		default:
+			return apperror.BadRequest.WithMessage("security must be one of starttls, tls, none")
+		}
+	}
+
+	if config.From != nil {
+		_, err := mail.ParseAddress(*config.From)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid from address")
+		}
+	}
+
+	if config.To != nil {
+		if len(*config.To) == 0 {
+			return apperror.BadRequest.WithMessage("at least one recipient is required")
+		}
+
+		for _, to := range *config.To {
+			_, err := mail.ParseAddress(to)
+			if err != nil {
+				return apperror.BadRequest.WithMessage("invalid recipient address " + to)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply переносит заданные поля в настройки email канала,
+// пароль не возвращается в ответах, поэтому маскированный пароль оставляет прежний
+func (config PatchEmailConfig) Apply(emailConfig entity.EmailConfig) entity.EmailConfig {
+	if config.Host != nil {
+		emailConfig.Host = *config.Host
+	}
+
+	if config.Port != nil {
+		emailConfig.Port = *config.Port
+	}
+
+	if config.Security != nil {
+		emailConfig.Security = *config.Security
+	}
+
+	if config.Username != nil {
+		emailConfig.Username = *config.Username
+	}
+
+	if config.Password != nil && *config.Password != secretMask {
+		emailConfig.Password = *config.Password
+	}
+
+	if config.From != nil {
+		emailConfig.From = *config.From
+	}
+
+	if config.To != nil {
+		emailConfig.To = *config.To
+	}
+
+	return emailConfig
+}
+
+type CreateChannelRequest struct {
+	Name    string         `json:"name"`
+	Type    string         `json:"type"`
+	Enabled *bool          `json:"enabled"`
+	Webhook *WebhookConfig `json:"webhook"`
+	Email   *EmailConfig   `json:"email"`
+}
+
+func (request CreateChannelRequest) Validate() error {
+	if request.Name == "" {
+		return apperror.BadRequest.WithMessage("name is required")
+	}
+
+	switch entity.ChannelType(request.Type) {
+	case entity.ChannelWebhook:
+		if request.Webhook == nil {
+			return apperror.BadRequest.WithMessage("webhook config is required")
+		}
+
+		return request.Webhook.Validate()
+	case entity.ChannelEmail:
+		if request.Email == nil {
+			return apperror.BadRequest.WithMessage("email config is required")
+		}
+
+		return request.Email.Validate()
+	default:
+		return apperror.BadRequest.WithMessage("unknown channel type " + request.Type)
+	}
+}
+
+func (request CreateChannelRequest) Channel() entity.Channel {
+	channel := entity.Channel{
+		Name:    request.Name,
+		Type:    entity.ChannelType(request.Type),
+		Enabled: true,
+	}
+
+	if request.Enabled != nil {
+		channel.Enabled = *request.Enabled
+	}
+
+	if request.Webhook != nil {
+		channel.Config.Webhook = &entity.WebhookConfig{
+			URL:    request.Webhook.URL,
+			Secret: request.Webhook.Secret,
+		}
+	}
+
+	if request.Email != nil {
+		channel.Config.Email = request.Email.entityConfig()
+	}
+
+	return channel
+}
+
+type PatchChannelRequest struct {
+	Name    *string           `json:"name"`
+	Enabled *bool             `json:"enabled"`
+	Webhook *WebhookConfig    `json:"webhook"`
+	Email   *PatchEmailConfig `json:"email"`
+}
+
+func (request PatchChannelRequest) Validate() error {
+	if request.Name != nil && *request.Name == "" {
+		return apperror.BadRequest.WithMessage("name is required")
+	}
+
+	if request.Webhook != nil {
+		err := request.Webhook.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	if request.Email != nil {
+		err := request.Email.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apply переносит заданные поля в канал, настройки другого типа канала игнорируются
+func (request PatchChannelRequest) Apply(channel entity.Channel) entity.Channel {
+	if request.Name != nil {
+		channel.Name = *request.Name
+	}
+
+	if request.Enabled != nil {
+		channel.Enabled = *request.Enabled
+	}
+
+	if request.Webhook != nil && channel.Type == entity.ChannelWebhook {
+		webhook := &entity.WebhookConfig{
+			URL:    request.Webhook.URL,
+			Secret: request.Webhook.Secret,
+		}
+
+		// секрет не возвращается в ответах, поэтому пустой или маскированный секрет оставляет прежний
+		if channel.Config.Webhook != nil && (webhook.Secret == "" || webhook.Secret == secretMask) {
+			webhook.Secret = channel.Config.Webhook.Secret
+		}
+
+		channel.Config.Webhook = webhook
+	}
+
+	if request.Email != nil && channel.Type == entity.ChannelEmail {
+		var email entity.EmailConfig
+		if channel.Config.Email != nil {
+			email = *channel.Config.Email
+		}
+
+		email = request.Email.Apply(email)
+		channel.Config.Email = &email
+	}
+
+	return channel
+}
+
+type ChannelResponse struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Type      string         `json:"type"`
+	Enabled   bool           `json:"enabled"`
+	CreatedAt time.Time      `json:"created_at"`
+	Webhook   *WebhookConfig `json:"webhook,omitempty"`
+	Email     *EmailConfig   `json:"email,omitempty"`
+}
+
+func NewChannelResponse(channel entity.Channel) ChannelResponse {
+	response := ChannelResponse{
+		ID:        channel.ID,
+		Name:      channel.Name,
+		Type:      string(channel.Type),
+		Enabled:   channel.Enabled,
+		CreatedAt: channel.CreatedAt,
+	}
+
+	if webhook := channel.Config.Webhook; webhook != nil {
+		response.Webhook = &WebhookConfig{
+			URL:    webhook.URL,
+			Secret: mask(webhook.Secret),
+		}
+	}
+
+	if email := channel.Config.Email; email != nil {
+		response.Email = &EmailConfig{
+			Host:     email.Host,
+			Port:     email.Port,
+			Security: email.Security,
+			Username: email.Username,
+			Password: mask(email.Password),
+			From:     email.From,
+			To:       email.To,
+		}
+	}
+
+	return response
+}
+
+type ChannelTestResponse struct {
+	Delivered bool   `json:"delivered"`
+	Error     string `json:"error,omitempty"`
+}
+
+func mask(secret string) string {
+	if secret == "" {
+		return ""
+	}
+
+	return secretMask
+}
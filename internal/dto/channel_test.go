@@ -0,0 +1,149 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"reflect"
+	"testing"
+)
+
+func TestPatchChannelKeepsWebhookSecret(t *testing.T) {
+	channel := entity.Channel{
+		Type: entity.ChannelWebhook,
+		Config: entity.ChannelConfig{Webhook: &entity.WebhookConfig{
+			URL:    "https://old.example.com/hook",
+			Secret: "stored secret",
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		secret string
+		want   string
+	}{
+		{name: "omitted", secret: "", want: "stored secret"},
+		{name: "masked", secret: secretMask, want: "stored secret"},
+		{name: "changed", secret: "new secret", want: "new secret"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := PatchChannelRequest{Webhook: &WebhookConfig{URL: "https://new.example.com/hook", Secret: test.secret}}
+
+			got := request.Apply(channel).Config.Webhook
+			if got.URL != "https://new.example.com/hook" {
+				t.Errorf("url = %q, want new url", got.URL)
+			}
+
+			if got.Secret != test.want {
+				t.Errorf("secret = %q, want %q", got.Secret, test.want)
+			}
+		})
+	}
+
+	if channel.Config.Webhook.URL != "https://old.example.com/hook" {
+		t.Error("apply must not modify stored config")
+	}
+}
+
+func TestPatchChannelMergesEmailConfig(t *testing.T) {
+	channel := entity.Channel{
+		Type: entity.ChannelEmail,
+		Config: entity.ChannelConfig{Email: &entity.EmailConfig{
+			Host:     "smtp.example.com",
+			Port:     587,
+			Security: entity.EmailSecurityStartTLS,
+			Username: "estimate",
+			Password: "stored password",
+			From:     "estimate@example.com",
+			To:       []string{"oncall@example.com"},
+		}},
+	}
+
+	port := 465
+	security := entity.EmailSecurityTLS
+	masked := secretMask
+	password := "new password"
+
+	tests := []struct {
+		name    string
+		request PatchEmailConfig
+		want    entity.EmailConfig
+	}{
+		{
+			name:    "password omitted",
+			request: PatchEmailConfig{Port: &port, Security: &security},
+			want: entity.EmailConfig{
+				Host:     "smtp.example.com",
+				Port:     465,
+				Security: entity.EmailSecurityTLS,
+				Username: "estimate",
+				Password: "stored password",
+				From:     "estimate@example.com",
+				To:       []string{"oncall@example.com"},
+			},
+		},
+		{
+			name:    "password masked",
+			request: PatchEmailConfig{Password: &masked},
+			want:    *channel.Config.Email,
+		},
+		{
+			name:    "password changed",
+			request: PatchEmailConfig{Password: &password},
+			want: entity.EmailConfig{
+				Host:     "smtp.example.com",
+				Port:     587,
+				Security: entity.EmailSecurityStartTLS,
+				Username: "estimate",
+				Password: "new password",
+				From:     "estimate@example.com",
+				To:       []string{"oncall@example.com"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := PatchChannelRequest{Email: &test.request}
+
+			err := request.Validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := request.Apply(channel).Config.Email
+			if !reflect.DeepEqual(*got, test.want) {
+				t.Errorf("email = %+v, want %+v", *got, test.want)
+			}
+		})
+	}
+
+	if channel.Config.Email.Port != 587 {
+		t.Error("apply must not modify stored config")
+	}
+}
+
+func TestPatchEmailConfigValidate(t *testing.T) {
+	empty := ""
+	port := 0
+	to := []string{}
+
+	tests := []struct {
+		name    string
+		request PatchEmailConfig
+	}{
+		{name: "empty host", request: PatchEmailConfig{Host: &empty}},
+		{name: "invalid port", request: PatchEmailConfig{Port: &port}},
+		{name: "invalid security", request: PatchEmailConfig{Security: &empty}},
+		{name: "invalid from", request: PatchEmailConfig{From: &empty}},
+		{name: "no recipients", request: PatchEmailConfig{To: &to}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.request.Validate() == nil {
+				t.Error("expected validation error")
+			}
+		})
+	}
+}
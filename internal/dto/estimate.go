@@ -2,8 +2,13 @@ package dto
 
 import (
 	"encoding/json"
+	"errors"
+	"estimate/internal/entity"
 	"estimate/pkg/apperror"
 	"github.com/goware/urlx"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -15,6 +20,39 @@ func (duration Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(duration.String())
 }
 
+func (duration *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	duration.Duration, err = time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type Phases struct {
+	DNS      Duration `json:"dns"`
+	Connect  Duration `json:"connect"`
+	TLS      Duration `json:"tls"`
+	TTFB     Duration `json:"ttfb"`
+	Transfer Duration `json:"transfer"`
+}
+
+func NewPhases(phases entity.Phases) Phases {
+	return Phases{
+		DNS:      Duration{Duration: phases.DNS},
+		Connect:  Duration{Duration: phases.Connect},
+		TLS:      Duration{Duration: phases.TLS},
+		TTFB:     Duration{Duration: phases.TTFB},
+		Transfer: Duration{Duration: phases.Transfer},
+	}
+}
+
 type GetWebsiteAccessTimeRequest struct {
 	URL string `query:"url"`
 }
@@ -29,18 +67,306 @@ func (request GetWebsiteAccessTimeRequest) Validate() error {
 }
 
 type GetWebsiteAccessTimeResponse struct {
+	Scheme      string    `json:"scheme"`
 	AccessTime  Duration  `json:"access_time"`
+	Phases      Phases    `json:"phases"`
 	LastCheckAt time.Time `json:"last_check_at"`
 }
 
+const MaxBatchURLs = 100
+
+type GetBatchRequest struct {
+	URLs []string `json:"urls"`
+}
+
+// Validate проверяет только размер пакета, ошибки отдельных адресов возвращаются в ответе для каждого адреса
+func (request GetBatchRequest) Validate() error {
+	if len(request.URLs) == 0 || len(request.URLs) > MaxBatchURLs {
+		return apperror.BadRequest.WithMessage("urls count must be between 1 and " + strconv.Itoa(MaxBatchURLs))
+	}
+
+	return nil
+}
+
+// BatchResultResponse содержит либо результат, либо ошибку для адреса
+type BatchResultResponse struct {
+	URL    string                        `json:"url"`
+	Result *GetWebsiteAccessTimeResponse `json:"result,omitempty"`
+	Error  *apperror.Error               `json:"error,omitempty"`
+}
+
+func NewBatchResultResponse(estimate entity.Estimate) BatchResultResponse {
+	response := BatchResultResponse{URL: estimate.URL}
+
+	if estimate.Err != nil {
+		var apperr apperror.Error
+		if !errors.As(estimate.Err, &apperr) {
+			apperr = apperror.Unknown.WithError(estimate.Err)
+		}
+		response.Error = &apperr
+
+		return response
+	}
+
+	response.Result = &GetWebsiteAccessTimeResponse{
+		Scheme:      estimate.Website.CheckedScheme,
+		AccessTime:  Duration{Duration: estimate.Website.AccessTime},
+		Phases:      NewPhases(estimate.Website.Phases),
+		LastCheckAt: estimate.Website.LastCheckAt,
+	}
+
+	return response
+}
+
+type GetBatchResponse struct {
+	Results []BatchResultResponse `json:"results"`
+}
+
 type GetWebsiteWithMinAccessTimeResponse struct {
 	URL         string    `json:"url"`
+	Scheme      string    `json:"scheme"`
 	AccessTime  Duration  `json:"access_time"`
+	Phases      Phases    `json:"phases"`
 	LastCheckAt time.Time `json:"last_check_at"`
 }
 
 type GetWebsiteWithMaxAccessTimeResponse struct {
 	URL         string    `json:"url"`
+	Scheme      string    `json:"scheme"`
 	AccessTime  Duration  `json:"access_time"`
+	Phases      Phases    `json:"phases"`
 	LastCheckAt time.Time `json:"last_check_at"`
 }
+
+const (
+	DefaultTopLimit = 10
+	MaxTopLimit     = 100
+)
+
+var tldRegexp = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)*$`)
+
+type GetTopRequest struct {
+	Order      string `query:"order"`
+	Limit      int    `query:"limit"`
+	TLD        string `query:"tld"`
+	Pattern    string `query:"pattern"`
+	StatusCode int    `query:"status_code"`
+}
+
+func (request GetTopRequest) Validate() error {
+	switch request.Order {
+	case "", OrderAsc, OrderDesc:
+	default:
+		return apperror.BadRequest.WithMessage("order must be one of asc, desc")
+	}
+
+	if request.Limit < 0 || request.Limit > MaxTopLimit {
+		return apperror.BadRequest.WithMessage("limit must be between 1 and " + strconv.Itoa(MaxTopLimit))
+	}
+
+	if request.TLD != "" && !tldRegexp.MatchString(strings.ToLower(strings.TrimPrefix(request.TLD, "."))) {
+		return apperror.BadRequest.WithMessage("invalid tld")
+	}
+
+	if request.StatusCode != 0 && (request.StatusCode < 100 || request.StatusCode > 599) {
+		return apperror.BadRequest.WithMessage("invalid status code")
+	}
+
+	return nil
+}
+
+// Filter возвращает фильтр рейтинга, по умолчанию первыми идут самые быстрые сайты
+func (request GetTopRequest) Filter() entity.TopFilter {
+	filter := entity.TopFilter{
+		Desc:       request.Order == OrderDesc,
+		Limit:      request.Limit,
+		TLD:        strings.ToLower(strings.TrimPrefix(request.TLD, ".")),
+		Pattern:    strings.ToLower(request.Pattern),
+		StatusCode: request.StatusCode,
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultTopLimit
+	}
+
+	return filter
+}
+
+type TopWebsiteResponse struct {
+	Rank        int       `json:"rank"`
+	URL         string    `json:"url"`
+	Scheme      string    `json:"scheme"`
+	StatusCode  int       `json:"status_code"`
+	AccessTime  Duration  `json:"access_time"`
+	Phases      Phases    `json:"phases"`
+	LastCheckAt time.Time `json:"last_check_at"`
+}
+
+func NewTopWebsiteResponse(website entity.RankedWebsite) TopWebsiteResponse {
+	return TopWebsiteResponse{
+		Rank:        website.Rank,
+		URL:         website.URL,
+		Scheme:      website.CheckedScheme,
+		StatusCode:  website.StatusCode,
+		AccessTime:  Duration{Duration: website.AccessTime},
+		Phases:      NewPhases(website.Phases),
+		LastCheckAt: website.LastCheckAt,
+	}
+}
+
+type GetWebsiteHistoryRequest struct {
+	URL  string    `query:"url"`
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+func (request GetWebsiteHistoryRequest) Validate() error {
+	_, err := urlx.Parse(request.URL)
+	if err != nil {
+		return apperror.BadRequest.WithMessage("invalid url")
+	}
+
+	if !request.From.IsZero() && !request.To.IsZero() && request.From.After(request.To) {
+		return apperror.BadRequest.WithMessage("from must be before to")
+	}
+
+	return nil
+}
+
+type CheckResponse struct {
+	CheckedAt       time.Time `json:"checked_at"`
+	AccessTime      Duration  `json:"access_time"`
+	Phases          Phases    `json:"phases"`
+	StatusCode      int       `json:"status_code"`
+	Scheme          string    `json:"scheme"`
+	Available       bool      `json:"available"`
+	FailedAssertion string    `json:"failed_assertion,omitempty"`
+	ErrorClass      string    `json:"error_class,omitempty"`
+	ErrorMessage    string    `json:"error_message,omitempty"`
+}
+
+func NewCheckResponse(check entity.Check) CheckResponse {
+	return CheckResponse{
+		CheckedAt:       check.CheckedAt,
+		AccessTime:      Duration{Duration: check.AccessTime},
+		Phases:          NewPhases(check.Phases),
+		StatusCode:      check.StatusCode,
+		Scheme:          check.Scheme,
+		Available:       check.Available,
+		FailedAssertion: check.FailedAssertion,
+		ErrorClass:      string(check.ErrorClass),
+		ErrorMessage:    check.ErrorMessage,
+	}
+}
+
+type GetWebsiteHistoryResponse struct {
+	URL    string          `json:"url"`
+	From   time.Time       `json:"from"`
+	To     time.Time       `json:"to"`
+	Checks []CheckResponse `json:"checks"`
+}
+
+const (
+	DefaultStatsWindow = 24 * time.Hour
+	MaxStatsWindow     = 30 * 24 * time.Hour
+)
+
+type GetWebsiteStatsRequest struct {
+	URL    string        `query:"url"`
+	Window time.Duration `query:"window"`
+}
+
+func (request GetWebsiteStatsRequest) Validate() error {
+	_, err := urlx.Parse(request.URL)
+	if err != nil {
+		return apperror.BadRequest.WithMessage("invalid url")
+	}
+
+	if request.Window < 0 || request.Window > MaxStatsWindow {
+		return apperror.BadRequest.WithMessage("window must be between 0 and " + MaxStatsWindow.String())
+	}
+
+	return nil
+}
+
+type GetWebsiteStatsResponse struct {
+	URL          string   `json:"url"`
+	Window       Duration `json:"window"`
+	Count        int      `json:"count"`
+	SuccessRatio float64  `json:"success_ratio"`
+	Min          Duration `json:"min"`
+	Max          Duration `json:"max"`
+	Mean         Duration `json:"mean"`
+	P50          Duration `json:"p50"`
+	P90          Duration `json:"p90"`
+	P99          Duration `json:"p99"`
+}
+
+// NewGetWebsiteStatsResponse возвращает статистику за окно, без проверок доля успешных равна нулю
+func NewGetWebsiteStatsResponse(url string, window time.Duration, stats entity.Stats) GetWebsiteStatsResponse {
+	response := GetWebsiteStatsResponse{
+		URL:    url,
+		Window: Duration{Duration: window},
+		Count:  stats.Count,
+		Min:    Duration{Duration: stats.Min},
+		Max:    Duration{Duration: stats.Max},
+		Mean:   Duration{Duration: stats.Mean},
+		P50:    Duration{Duration: stats.P50},
+		P90:    Duration{Duration: stats.P90},
+		P99:    Duration{Duration: stats.P99},
+	}
+	if stats.Count > 0 {
+		response.SuccessRatio = float64(stats.SuccessCount) / float64(stats.Count)
+	}
+
+	return response
+}
+
+type GetWebsiteRedirectsRequest struct {
+	URL string `query:"url"`
+}
+
+func (request GetWebsiteRedirectsRequest) Validate() error {
+	_, err := urlx.Parse(request.URL)
+	if err != nil {
+		return apperror.BadRequest.WithMessage("invalid url")
+	}
+
+	return nil
+}
+
+type HopResponse struct {
+	URL        string   `json:"url"`
+	StatusCode int      `json:"status_code"`
+	Latency    Duration `json:"latency"`
+}
+
+type GetWebsiteRedirectsResponse struct {
+	URL       string        `json:"url"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Count     int           `json:"count"`
+	Latency   Duration      `json:"latency"`
+	Hops      []HopResponse `json:"hops"`
+}
+
+func NewGetWebsiteRedirectsResponse(url string, check entity.Check) GetWebsiteRedirectsResponse {
+	response := GetWebsiteRedirectsResponse{
+		URL:       url,
+		CheckedAt: check.CheckedAt,
+		Hops:      make([]HopResponse, len(check.Redirects)),
+	}
+	if len(check.Redirects) > 0 {
+		response.Count = len(check.Redirects) - 1
+	}
+
+	for i, hop := range check.Redirects {
+		response.Latency.Duration += hop.Latency
+		response.Hops[i] = HopResponse{
+			URL:        hop.URL,
+			StatusCode: hop.StatusCode,
+			Latency:    Duration{Duration: hop.Latency},
+		}
+	}
+
+	return response
+}
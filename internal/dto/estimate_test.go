@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"testing"
+	"time"
+)
+
+func TestNewGetWebsiteStatsResponse(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats entity.Stats
+		want  GetWebsiteStatsResponse
+	}{
+		{
+			name: "no checks",
+			want: GetWebsiteStatsResponse{URL: "example.com", Window: Duration{Duration: time.Hour}},
+		},
+		{
+			name:  "all failed",
+			stats: entity.Stats{Count: 4},
+			want:  GetWebsiteStatsResponse{URL: "example.com", Window: Duration{Duration: time.Hour}, Count: 4},
+		},
+		{
+			name: "percentiles",
+			stats: entity.Stats{
+				Count:        4,
+				SuccessCount: 3,
+				Min:          100 * time.Millisecond,
+				Max:          900 * time.Millisecond,
+				Mean:         400 * time.Millisecond,
+				P50:          200 * time.Millisecond,
+				P90:          780 * time.Millisecond,
+				P99:          888 * time.Millisecond,
+			},
+			want: GetWebsiteStatsResponse{
+				URL:          "example.com",
+				Window:       Duration{Duration: time.Hour},
+				Count:        4,
+				SuccessRatio: 0.75,
+				Min:          Duration{Duration: 100 * time.Millisecond},
+				Max:          Duration{Duration: 900 * time.Millisecond},
+				Mean:         Duration{Duration: 400 * time.Millisecond},
+				P50:          Duration{Duration: 200 * time.Millisecond},
+				P90:          Duration{Duration: 780 * time.Millisecond},
+				P99:          Duration{Duration: 888 * time.Millisecond},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := NewGetWebsiteStatsResponse("example.com", time.Hour, test.stats)
+			if got != test.want {
+				t.Errorf("response = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
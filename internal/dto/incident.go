@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"github.com/goware/urlx"
+	"time"
+)
+
+type GetIncidentsRequest struct {
+	URL  string    `query:"url"`
+	From time.Time `query:"from"`
+	To   time.Time `query:"to"`
+}
+
+func (request GetIncidentsRequest) Validate() error {
+	if request.URL != "" {
+		_, err := urlx.Parse(request.URL)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid url")
+		}
+	}
+
+	if !request.From.IsZero() && !request.To.IsZero() && request.From.After(request.To) {
+		return apperror.BadRequest.WithMessage("from must be before to")
+	}
+
+	return nil
+}
+
+type IncidentResponse struct {
+	ID        int64      `json:"id"`
+	URL       string     `json:"url"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Duration  Duration   `json:"duration"`
+	Cause     Failure    `json:"cause"`
+}
+
+func NewIncidentResponse(incident entity.Incident) IncidentResponse {
+	endedAt := time.Now()
+	if incident.EndedAt != nil {
+		endedAt = *incident.EndedAt
+	}
+
+	return IncidentResponse{
+		ID:        incident.ID,
+		URL:       incident.URL,
+		StartedAt: incident.StartedAt,
+		EndedAt:   incident.EndedAt,
+		Duration:  Duration{Duration: endedAt.Sub(incident.StartedAt)},
+		Cause: Failure{
+			Class:   string(incident.CauseClass),
+			Message: incident.CauseMessage,
+		},
+	}
+}
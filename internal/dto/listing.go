@@ -0,0 +1,185 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultWebsitesLimit = 50
+	MaxWebsitesLimit     = 500
+)
+
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+type GetWebsitesRequest struct {
+	Cursor        string    `query:"cursor"`
+	Limit         int       `query:"limit"`
+	Sort          string    `query:"sort"`
+	Order         string    `query:"order"`
+	StatusCode    int       `query:"status_code"`
+	Available     *bool     `query:"available"`
+	URLPrefix     string    `query:"url_prefix"`
+	CheckedBefore time.Time `query:"checked_before"`
+}
+
+func (request GetWebsitesRequest) Validate() error {
+	if request.Limit < 0 || request.Limit > MaxWebsitesLimit {
+		return apperror.BadRequest.WithMessage("limit must be between 1 and " + strconv.Itoa(MaxWebsitesLimit))
+	}
+
+	switch entity.WebsiteSort(request.Sort) {
+	case "", entity.WebsiteSortURL, entity.WebsiteSortAccessTime, entity.WebsiteSortLastCheckAt:
+	default:
+		return apperror.BadRequest.WithMessage("sort must be one of url, access_time, last_check_at")
+	}
+
+	switch request.Order {
+	case "", OrderAsc, OrderDesc:
+	default:
+		return apperror.BadRequest.WithMessage("order must be one of asc, desc")
+	}
+
+	if request.StatusCode != 0 && (request.StatusCode < 100 || request.StatusCode > 599) {
+		return apperror.BadRequest.WithMessage("invalid status code")
+	}
+
+	return nil
+}
+
+// Filter возвращает фильтр страницы, по умолчанию сайты сортируются по адресу,
+// курсор должен быть получен с той же сортировкой
+func (request GetWebsitesRequest) Filter() (entity.WebsiteFilter, error) {
+	filter := entity.WebsiteFilter{
+		StatusCode:    request.StatusCode,
+		Available:     request.Available,
+		URLPrefix:     request.URLPrefix,
+		CheckedBefore: request.CheckedBefore,
+		Sort:          entity.WebsiteSort(request.Sort),
+		Desc:          request.Order == OrderDesc,
+		Limit:         request.Limit,
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = entity.WebsiteSortURL
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultWebsitesLimit
+	}
+
+	if request.Cursor != "" {
+		c, err := decodeCursor(request.Cursor)
+		if err != nil {
+			return entity.WebsiteFilter{}, err
+		}
+
+		if c.Sort != filter.Sort || c.Desc != filter.Desc {
+			return entity.WebsiteFilter{}, apperror.BadRequest.WithMessage("cursor does not match sort and order")
+		}
+
+		filter.After = &entity.WebsiteCursor{
+			URL:        c.URL,
+			AccessTime: c.AccessTime,
+			ID:         c.ID,
+		}
+		if c.LastCheckAt != nil {
+			filter.After.LastCheckAt = *c.LastCheckAt
+		}
+	}
+
+	return filter, nil
+}
+
+// cursor содержимое непрозрачного курсора страницы, вместе с позицией хранит сортировку,
+// чтобы курсор нельзя было применить к другому порядку
+type cursor struct {
+	Sort        entity.WebsiteSort `json:"s"`
+	Desc        bool               `json:"d,omitempty"`
+	URL         string             `json:"u,omitempty"`
+	AccessTime  time.Duration      `json:"a,omitempty"`
+	LastCheckAt *time.Time         `json:"l,omitempty"`
+	ID          int64              `json:"i"`
+}
+
+// EncodeCursor возвращает курсор следующей страницы, хранится только значение поля сортировки
+func EncodeCursor(filter entity.WebsiteFilter, next entity.WebsiteCursor) string {
+	c := cursor{
+		Sort: filter.Sort,
+		Desc: filter.Desc,
+		ID:   next.ID,
+	}
+
+	switch filter.Sort {
+	case entity.WebsiteSortAccessTime:
+		c.AccessTime = next.AccessTime
+	case entity.WebsiteSortLastCheckAt:
+		c.LastCheckAt = &next.LastCheckAt
+	default:
+		c.URL = next.URL
+	}
+
+	data, _ := json.Marshal(c)
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor{}, apperror.BadRequest.WithMessage("invalid cursor")
+	}
+
+	var c cursor
+	err = json.Unmarshal(data, &c)
+	if err != nil {
+		return cursor{}, apperror.BadRequest.WithMessage("invalid cursor")
+	}
+
+	return c, nil
+}
+
+// WebsiteSummaryResponse результат последней проверки сайта без настроек проверки,
+// которые могут содержать секреты в заголовках и теле запроса
+type WebsiteSummaryResponse struct {
+	ID          int64     `json:"id"`
+	URL         string    `json:"url"`
+	State       string    `json:"state"`
+	Available   bool      `json:"available"`
+	StatusCode  int       `json:"status_code"`
+	Scheme      string    `json:"checked_scheme"`
+	AccessTime  Duration  `json:"access_time"`
+	Phases      Phases    `json:"phases"`
+	LastCheckAt time.Time `json:"last_check_at"`
+	Failure     Failure   `json:"failure"`
+}
+
+func NewWebsiteSummaryResponse(website entity.Website) WebsiteSummaryResponse {
+	return WebsiteSummaryResponse{
+		ID:          website.ID,
+		URL:         website.URL,
+		State:       string(website.State),
+		Available:   website.Available,
+		StatusCode:  website.StatusCode,
+		Scheme:      website.CheckedScheme,
+		AccessTime:  Duration{Duration: website.AccessTime},
+		Phases:      NewPhases(website.Phases),
+		LastCheckAt: website.LastCheckAt,
+		Failure: Failure{
+			Class:   string(website.ErrorClass),
+			Message: website.ErrorMessage,
+		},
+	}
+}
+
+type GetWebsitesResponse struct {
+	Websites   []WebsiteSummaryResponse `json:"websites"`
+	NextCursor string                   `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	next := entity.WebsiteCursor{
+		URL:         "example.com",
+		AccessTime:  120 * time.Millisecond,
+		LastCheckAt: time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+		ID:          42,
+	}
+
+	tests := []struct {
+		name  string
+		sort  string
+		order string
+		want  entity.WebsiteCursor
+	}{
+		{name: "url", sort: "url", order: OrderAsc, want: entity.WebsiteCursor{URL: next.URL, ID: next.ID}},
+		{name: "default sort", order: OrderDesc, want: entity.WebsiteCursor{URL: next.URL, ID: next.ID}},
+		{name: "access time", sort: "access_time", want: entity.WebsiteCursor{AccessTime: next.AccessTime, ID: next.ID}},
+		{
+			name:  "last check at",
+			sort:  "last_check_at",
+			order: OrderDesc,
+			want:  entity.WebsiteCursor{LastCheckAt: next.LastCheckAt, ID: next.ID},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := GetWebsitesRequest{Sort: test.sort, Order: test.order}
+
+			filter, err := request.Filter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			request.Cursor = EncodeCursor(filter, next)
+
+			filter, err = request.Filter()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if filter.After == nil {
+				t.Fatal("cursor is not applied")
+			}
+
+			after := *filter.After
+			if after.URL != test.want.URL || after.AccessTime != test.want.AccessTime ||
+				!after.LastCheckAt.Equal(test.want.LastCheckAt) || after.ID != test.want.ID {
+				t.Errorf("after = %+v, want %+v", after, test.want)
+			}
+		})
+	}
+}
+
+func TestCursorInvalid(t *testing.T) {
+	urlCursor := EncodeCursor(entity.WebsiteFilter{Sort: entity.WebsiteSortURL}, entity.WebsiteCursor{URL: "example.com", ID: 1})
+
+	tests := []struct {
+		name    string
+		request GetWebsitesRequest
+	}{
+		{name: "not base64", request: GetWebsitesRequest{Cursor: "!!!"}},
+		{name: "not json", request: GetWebsitesRequest{Cursor: "bm90IGpzb24"}},
+		{name: "other sort", request: GetWebsitesRequest{Cursor: urlCursor, Sort: "access_time"}},
+		{name: "other order", request: GetWebsitesRequest{Cursor: urlCursor, Order: OrderDesc}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.request.Filter()
+			if _, ok := apperror.Is(err, apperror.BadRequest); !ok {
+				t.Errorf("err = %v, want bad request", err)
+			}
+		})
+	}
+}
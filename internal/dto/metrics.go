@@ -0,0 +1,124 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMetricsWindow      = 24 * time.Hour
+	DefaultMetricsGranularity = time.Hour
+	MaxMetricsPoints          = 1440
+)
+
+type GetMetricsRequest struct {
+	From        time.Time     `query:"from"`
+	To          time.Time     `query:"to"`
+	Granularity time.Duration `query:"granularity"`
+}
+
+func (request GetMetricsRequest) Validate() error {
+	if !request.From.IsZero() && !request.To.IsZero() && !request.From.Before(request.To) {
+		return apperror.BadRequest.WithMessage("from must be before to")
+	}
+
+	if request.Granularity < 0 || request.Granularity%time.Minute != 0 {
+		return apperror.BadRequest.WithMessage("granularity must be a positive multiple of a minute")
+	}
+
+	return nil
+}
+
+// Filter возвращает фильтр с периодом по умолчанию за последние сутки с шагом в час,
+// метрик из будущего нет, поэтому to ограничивается текущим временем
+func (request GetMetricsRequest) Filter() (entity.MetricsFilter, error) {
+	filter := entity.MetricsFilter{
+		From:        request.From,
+		To:          request.To,
+		Granularity: request.Granularity,
+	}
+
+	if now := time.Now(); filter.To.IsZero() || filter.To.After(now) {
+		filter.To = now
+	}
+
+	if !filter.From.IsZero() && !filter.From.Before(filter.To) {
+		return entity.MetricsFilter{}, apperror.BadRequest.WithMessage("from must be in the past")
+	}
+
+	if filter.From.IsZero() {
+		filter.From = filter.To.Add(-DefaultMetricsWindow)
+	}
+
+	if filter.Granularity == 0 {
+		filter.Granularity = DefaultMetricsGranularity
+	}
+
+	if filter.To.Sub(filter.From)/filter.Granularity > MaxMetricsPoints {
+		return entity.MetricsFilter{}, apperror.BadRequest.WithMessage("too many points, at most " + strconv.Itoa(MaxMetricsPoints) + " are allowed")
+	}
+
+	return filter, nil
+}
+
+type MetricPointResponse struct {
+	Time        time.Time `json:"time"`
+	Count       int       `json:"count"`
+	LatencyMean Duration  `json:"latency_mean"`
+}
+
+type LatencyBucketResponse struct {
+	Le    string `json:"le"`
+	Count int    `json:"count"`
+}
+
+type MetricResponse struct {
+	Endpoint    string                  `json:"endpoint"`
+	Method      string                  `json:"method"`
+	StatusClass string                  `json:"status_class"`
+	Count       int                     `json:"count"`
+	LatencySum  Duration                `json:"latency_sum"`
+	LatencyMean Duration                `json:"latency_mean"`
+	Buckets     []LatencyBucketResponse `json:"buckets"`
+	Points      []MetricPointResponse   `json:"points"`
+}
+
+func NewMetricResponse(metric entity.Metric) MetricResponse {
+	response := MetricResponse{
+		Endpoint:    metric.Endpoint,
+		Method:      metric.Method,
+		StatusClass: metric.StatusClass,
+		Count:       metric.Count,
+		LatencySum:  Duration{Duration: metric.LatencySum},
+		Buckets:     make([]LatencyBucketResponse, len(metric.Buckets)),
+		Points:      make([]MetricPointResponse, len(metric.Points)),
+	}
+
+	if metric.Count > 0 {
+		response.LatencyMean = Duration{Duration: metric.LatencySum / time.Duration(metric.Count)}
+	}
+
+	for i, bucket := range metric.Buckets {
+		le := "+Inf"
+		if bucket.Le > 0 {
+			le = bucket.Le.String()
+		}
+
+		response.Buckets[i] = LatencyBucketResponse{Le: le, Count: bucket.Count}
+	}
+
+	for i, point := range metric.Points {
+		response.Points[i] = MetricPointResponse{
+			Time:  point.Time,
+			Count: point.Count,
+		}
+
+		if point.Count > 0 {
+			response.Points[i].LatencyMean = Duration{Duration: point.LatencySum / time.Duration(point.Count)}
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"time"
+)
+
+// StatusWebsite строка страницы статуса, страница доступна без авторизации,
+// поэтому вместо текста ошибки показывается только ее класс
+type StatusWebsite struct {
+	URL         string
+	State       entity.State
+	Available   bool
+	AccessTime  time.Duration
+	LastCheckAt time.Time
+	ErrorClass  entity.ErrorClass
+}
+
+func NewStatusWebsite(website entity.Website) StatusWebsite {
+	if website.State == entity.StateUnknown {
+		return StatusWebsite{URL: website.URL, State: website.State}
+	}
+
+	return StatusWebsite{
+		URL:         website.URL,
+		State:       website.State,
+		Available:   website.Available,
+		AccessTime:  website.AccessTime.Round(time.Millisecond),
+		LastCheckAt: website.LastCheckAt,
+		ErrorClass:  website.ErrorClass,
+	}
+}
+
+// StatusPage данные HTML страницы статуса, Fastest и Slowest выбираются среди доступных сайтов,
+// Up и Checked считаются без еще не проверявшихся сайтов
+type StatusPage struct {
+	GeneratedAt time.Time
+	Up          int
+	Checked     int
+	Websites    []StatusWebsite
+	Fastest     *StatusWebsite
+	Slowest     *StatusWebsite
+}
+
+func NewStatusPage(websites []entity.Website) StatusPage {
+	page := StatusPage{
+		GeneratedAt: time.Now(),
+		Websites:    make([]StatusWebsite, len(websites)),
+	}
+
+	for i, website := range websites {
+		page.Websites[i] = NewStatusWebsite(website)
+
+		if website.State == entity.StateUnknown {
+			continue
+		}
+
+		page.Checked++
+		if website.State == entity.StateUp {
+			page.Up++
+		}
+
+		if !website.Available {
+			continue
+		}
+
+		if page.Fastest == nil || website.AccessTime < page.Fastest.AccessTime {
+			page.Fastest = &page.Websites[i]
+		}
+
+		if page.Slowest == nil || website.AccessTime > page.Slowest.AccessTime {
+			page.Slowest = &page.Websites[i]
+		}
+	}
+
+	return page
+}
@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"github.com/goware/urlx"
+	"strconv"
+)
+
+const MaxStreamURLs = 100
+
+type GetResultStreamRequest struct {
+	URLs []string `query:"url"`
+}
+
+func (request GetResultStreamRequest) Validate() error {
+	if len(request.URLs) > MaxStreamURLs {
+		return apperror.BadRequest.WithMessage("url count must not exceed " + strconv.Itoa(MaxStreamURLs))
+	}
+
+	for _, url := range request.URLs {
+		_, err := urlx.Parse(url)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid url " + url)
+		}
+	}
+
+	return nil
+}
+
+type ResultResponse struct {
+	URL   string        `json:"url"`
+	State string        `json:"state"`
+	Check CheckResponse `json:"check"`
+}
+
+func NewResultResponse(result entity.Result) ResultResponse {
+	return ResultResponse{
+		URL:   result.Website.URL,
+		State: string(result.Website.State),
+		Check: NewCheckResponse(result.Check),
+	}
+}
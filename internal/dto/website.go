@@ -0,0 +1,343 @@
+package dto
+
+import (
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"fmt"
+	"github.com/goware/urlx"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimeout      = 10 * time.Second
+	MaxTimeout          = 60 * time.Second
+	DefaultMaxRedirects = 10
+	RedirectsLimit      = 30
+)
+
+var allowedMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodPost:    {},
+	http.MethodPut:     {},
+	http.MethodPatch:   {},
+	http.MethodDelete:  {},
+	http.MethodOptions: {},
+}
+
+type Assertion struct {
+	Type   string `json:"type"`
+	Target string `json:"target,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+func (assertion Assertion) Validate() error {
+	switch entity.AssertionType(assertion.Type) {
+	case entity.AssertionBodyContains, entity.AssertionBodyNotContains:
+		if assertion.Value == "" {
+			return apperror.BadRequest.WithMessage(assertion.Type + " requires value")
+		}
+	case entity.AssertionBodyRegex:
+		_, err := regexp.Compile(assertion.Value)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid regex " + assertion.Value)
+		}
+	case entity.AssertionJSONPath, entity.AssertionHeaderPresent:
+		if assertion.Target == "" {
+			return apperror.BadRequest.WithMessage(assertion.Type + " requires target")
+		}
+	case entity.AssertionHeaderMatches:
+		if assertion.Target == "" {
+			return apperror.BadRequest.WithMessage(assertion.Type + " requires target")
+		}
+
+		_, err := regexp.Compile(assertion.Value)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid regex " + assertion.Value)
+		}
+	case entity.AssertionMaxBodySize:
+		size, err := strconv.ParseInt(assertion.Value, 10, 64)
+		if err != nil || size <= 0 {
+			return apperror.BadRequest.WithMessage("invalid max body size " + assertion.Value)
+		}
+	default:
+		return apperror.BadRequest.WithMessage("unknown assertion type " + assertion.Type)
+	}
+
+	return nil
+}
+
+func NewAssertions(assertions []entity.Assertion) []Assertion {
+	result := make([]Assertion, len(assertions))
+	for i, assertion := range assertions {
+		result[i] = Assertion{
+			Type:   string(assertion.Type),
+			Target: assertion.Target,
+			Value:  assertion.Value,
+		}
+	}
+
+	return result
+}
+
+type CheckConfig struct {
+	Scheme           *string            `json:"scheme"`
+	Method           *string            `json:"method"`
+	Headers          *map[string]string `json:"headers"`
+	Body             *string            `json:"body"`
+	AcceptedStatuses *[]string          `json:"accepted_statuses"`
+	Timeout          *Duration          `json:"timeout"`
+	FollowRedirects  *bool              `json:"follow_redirects"`
+	MaxRedirects     *int               `json:"max_redirects"`
+	Assertions       *[]Assertion       `json:"assertions"`
+}
+
+func (config CheckConfig) Validate() error {
+	if config.Scheme != nil {
+		switch *config.Scheme {
+		case entity.SchemeHTTPS, entity.SchemeHTTP, entity.SchemeAuto:
+		default:
+			return apperror.BadRequest.WithMessage("scheme must be one of https, http, auto")
+		}
+	}
+
+	if config.Method != nil {
+		if _, ok := allowedMethods[strings.ToUpper(*config.Method)]; !ok {
+			return apperror.BadRequest.WithMessage("invalid method")
+		}
+	}
+
+	if config.AcceptedStatuses != nil {
+		_, err := ParseStatusRanges(*config.AcceptedStatuses)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.Timeout != nil && (config.Timeout.Duration <= 0 || config.Timeout.Duration > MaxTimeout) {
+		return apperror.BadRequest.WithMessage("timeout must be between 0 and " + MaxTimeout.String())
+	}
+
+	if config.MaxRedirects != nil && (*config.MaxRedirects < 1 || *config.MaxRedirects > RedirectsLimit) {
+		return apperror.BadRequest.WithMessage("max redirects must be between 1 and " + strconv.Itoa(RedirectsLimit))
+	}
+
+	if config.Assertions != nil {
+		for _, assertion := range *config.Assertions {
+			err := assertion.Validate()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Apply переносит заданные поля в настройки проверки
+func (config CheckConfig) Apply(checkConfig entity.CheckConfig) entity.CheckConfig {
+	if config.Scheme != nil {
+		checkConfig.Scheme = *config.Scheme
+	}
+
+	if config.Method != nil {
+		checkConfig.Method = strings.ToUpper(*config.Method)
+	}
+
+	if config.Headers != nil {
+		checkConfig.Headers = *config.Headers
+	}
+
+	if config.Body != nil {
+		checkConfig.Body = *config.Body
+	}
+
+	if config.AcceptedStatuses != nil {
+		checkConfig.AcceptedStatuses, _ = ParseStatusRanges(*config.AcceptedStatuses)
+	}
+
+	if config.Timeout != nil {
+		checkConfig.Timeout = config.Timeout.Duration
+	}
+
+	if config.FollowRedirects != nil {
+		checkConfig.FollowRedirects = *config.FollowRedirects
+	}
+
+	if config.MaxRedirects != nil {
+		checkConfig.MaxRedirects = *config.MaxRedirects
+	}
+
+	if config.Assertions != nil {
+		checkConfig.Assertions = make([]entity.Assertion, len(*config.Assertions))
+		for i, assertion := range *config.Assertions {
+			checkConfig.Assertions[i] = entity.Assertion{
+				Type:   entity.AssertionType(assertion.Type),
+				Target: assertion.Target,
+				Value:  assertion.Value,
+			}
+		}
+	}
+
+	if checkConfig.Headers == nil {
+		checkConfig.Headers = map[string]string{}
+	}
+
+	if checkConfig.Assertions == nil {
+		checkConfig.Assertions = []entity.Assertion{}
+	}
+
+	return checkConfig
+}
+
+func DefaultCheckConfig() entity.CheckConfig {
+	return entity.CheckConfig{
+		Scheme:           entity.SchemeHTTPS,
+		Method:           http.MethodGet,
+		Headers:          map[string]string{},
+		AcceptedStatuses: []entity.StatusRange{{From: http.StatusOK, To: http.StatusOK}},
+		Timeout:          DefaultTimeout,
+		FollowRedirects:  true,
+		MaxRedirects:     DefaultMaxRedirects,
+	}
+}
+
+// ParseStatusRanges разбирает коды ответа вида "200" и диапазоны вида "200-299"
+func ParseStatusRanges(values []string) ([]entity.StatusRange, error) {
+	if len(values) == 0 {
+		return nil, apperror.BadRequest.WithMessage("accepted statuses must not be empty")
+	}
+
+	ranges := make([]entity.StatusRange, len(values))
+	for i, value := range values {
+		rawFrom, rawTo, found := strings.Cut(value, "-")
+		if !found {
+			rawTo = rawFrom
+		}
+
+		from, err := strconv.Atoi(strings.TrimSpace(rawFrom))
+		if err != nil {
+			return nil, apperror.BadRequest.WithMessage("invalid status " + value)
+		}
+
+		to, err := strconv.Atoi(strings.TrimSpace(rawTo))
+		if err != nil {
+			return nil, apperror.BadRequest.WithMessage("invalid status " + value)
+		}
+
+		if from < 100 || to > 599 || from > to {
+			return nil, apperror.BadRequest.WithMessage("invalid status " + value)
+		}
+
+		ranges[i] = entity.StatusRange{From: from, To: to}
+	}
+
+	return ranges, nil
+}
+
+func FormatStatusRanges(ranges []entity.StatusRange) []string {
+	values := make([]string, len(ranges))
+	for i, r := range ranges {
+		if r.From == r.To {
+			values[i] = strconv.Itoa(r.From)
+		} else {
+			values[i] = fmt.Sprintf("%d-%d", r.From, r.To)
+		}
+	}
+
+	return values
+}
+
+type CreateWebsiteRequest struct {
+	URL string `json:"url"`
+	CheckConfig
+}
+
+func (request CreateWebsiteRequest) Validate() error {
+	_, err := urlx.Parse(request.URL)
+	if err != nil {
+		return apperror.BadRequest.WithMessage("invalid url")
+	}
+
+	return request.CheckConfig.Validate()
+}
+
+type PatchWebsiteRequest struct {
+	URL *string `json:"url"`
+	CheckConfig
+}
+
+func (request PatchWebsiteRequest) Validate() error {
+	if request.URL != nil {
+		_, err := urlx.Parse(*request.URL)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid url")
+		}
+	}
+
+	return request.CheckConfig.Validate()
+}
+
+type Failure struct {
+	Class   string `json:"class"`
+	Message string `json:"message"`
+}
+
+type CheckConfigResponse struct {
+	Scheme           string            `json:"scheme"`
+	Method           string            `json:"method"`
+	Headers          map[string]string `json:"headers"`
+	Body             string            `json:"body"`
+	AcceptedStatuses []string          `json:"accepted_statuses"`
+	Timeout          Duration          `json:"timeout"`
+	FollowRedirects  bool              `json:"follow_redirects"`
+	MaxRedirects     int               `json:"max_redirects"`
+	Assertions       []Assertion       `json:"assertions"`
+}
+
+type WebsiteResponse struct {
+	ID          int64               `json:"id"`
+	URL         string              `json:"url"`
+	LastCheckAt time.Time           `json:"last_check_at"`
+	AccessTime  Duration            `json:"access_time"`
+	Phases      Phases              `json:"phases"`
+	StatusCode  int                 `json:"status_code"`
+	Available   bool                `json:"available"`
+	State       string              `json:"state"`
+	Scheme      string              `json:"checked_scheme"`
+	Failure     Failure             `json:"failure"`
+	CheckConfig CheckConfigResponse `json:"check_config"`
+}
+
+func NewWebsiteResponse(website entity.Website) WebsiteResponse {
+	return WebsiteResponse{
+		ID:          website.ID,
+		URL:         website.URL,
+		LastCheckAt: website.LastCheckAt,
+		AccessTime:  Duration{Duration: website.AccessTime},
+		Phases:      NewPhases(website.Phases),
+		StatusCode:  website.StatusCode,
+		Available:   website.Available,
+		State:       string(website.State),
+		Scheme:      website.CheckedScheme,
+		Failure: Failure{
+			Class:   string(website.ErrorClass),
+			Message: website.ErrorMessage,
+		},
+		CheckConfig: CheckConfigResponse{
+			Scheme:           website.Scheme,
+			Method:           website.Method,
+			Headers:          website.Headers,
+			Body:             website.Body,
+			AcceptedStatuses: FormatStatusRanges(website.AcceptedStatuses),
+			Timeout:          Duration{Duration: website.Timeout},
+			FollowRedirects:  website.FollowRedirects,
+			MaxRedirects:     website.MaxRedirects,
+			Assertions:       NewAssertions(website.Assertions),
+		},
+	}
+}
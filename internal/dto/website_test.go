@@ -0,0 +1,30 @@
+package dto
+
+import (
+	"estimate/pkg/apperror"
+	"testing"
+)
+
+func TestCreateWebsiteRequestInvalidRegex(t *testing.T) {
+	tests := []struct {
+		name      string
+		assertion Assertion
+	}{
+		{name: "body regex", assertion: Assertion{Type: "body_regex", Value: "("}},
+		{name: "header matches", assertion: Assertion{Type: "header_matches", Target: "Content-Type", Value: "[a-"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := CreateWebsiteRequest{
+				URL:         "example.com",
+				CheckConfig: CheckConfig{Assertions: &[]Assertion{test.assertion}},
+			}
+
+			err := request.Validate()
+			if _, ok := apperror.Is(err, apperror.BadRequest); !ok {
+				t.Errorf("err = %v, want bad request", err)
+			}
+		})
+	}
+}
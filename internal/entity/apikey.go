@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+type Scope string
+
+const (
+	ScopeEstimatesRead Scope = "estimates:read"
+	ScopeWebsitesWrite Scope = "websites:write"
+	ScopeMetricsRead   Scope = "metrics:read"
+	// ScopeAdmin дает доступ ко всем endpoints, в том числе к управлению ключами и каналами уведомлений
+	ScopeAdmin Scope = "admin"
+)
+
+var Scopes = []Scope{ScopeEstimatesRead, ScopeWebsitesWrite, ScopeMetricsRead, ScopeAdmin}
+
+// APIKey ключ доступа к API, в базе хранится только хеш ключа
+type APIKey struct {
+	ID         int64      `db:"id" json:"id"`
+	Name       string     `db:"name" json:"name"`
+	Prefix     string     `db:"prefix" json:"prefix"`
+	Hash       string     `db:"hash" json:"-"`
+	Scopes     []string   `db:"scopes" json:"scopes"`
+	CreatedAt  time.Time  `db:"created_at" json:"created_at"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revoked_at"`
+}
+
+func (key APIKey) Allows(scope Scope) bool {
+	for _, s := range key.Scopes {
+		if Scope(s) == scope || Scope(s) == ScopeAdmin {
+			return true
+		}
+	}
+
+	return false
+}
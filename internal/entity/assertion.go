@@ -0,0 +1,21 @@
+package entity
+
+type AssertionType string
+
+const (
+	AssertionBodyContains    AssertionType = "body_contains"
+	AssertionBodyNotContains AssertionType = "body_not_contains"
+	AssertionBodyRegex       AssertionType = "body_regex"
+	AssertionJSONPath        AssertionType = "json_path"
+	AssertionHeaderPresent   AssertionType = "header_present"
+	AssertionHeaderMatches   AssertionType = "header_matches"
+	AssertionMaxBodySize     AssertionType = "max_body_size"
+)
+
+// Assertion проверка содержимого ответа, Target - имя заголовка или путь в JSON,
+// Value - ожидаемый текст, регулярное выражение, значение или размер в байтах
+type Assertion struct {
+	Type   AssertionType `json:"type"`
+	Target string        `json:"target,omitempty"`
+	Value  string        `json:"value,omitempty"`
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Certificate сведения о сертификате сайта, ExpiresAt - ближайшая дата истечения в цепочке
+type Certificate struct {
+	ExpiresAt *time.Time `db:"cert_expires_at" json:"expires_at"`
+	Issuer    string     `db:"cert_issuer" json:"issuer"`
+	SANs      []string   `db:"cert_sans" json:"sans"`
+	Error     string     `db:"cert_error" json:"error"`
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+type ChannelType string
+
+const (
+	ChannelWebhook ChannelType = "webhook"
+	ChannelEmail   ChannelType = "email"
+)
+
+// Channel канал, в который отправляются уведомления об изменении состояния сайтов
+type Channel struct {
+	ID        int64         `db:"id" json:"id"`
+	Name      string        `db:"name" json:"name"`
+	Type      ChannelType   `db:"type" json:"type"`
+	Config    ChannelConfig `db:"config" json:"config"`
+	Enabled   bool          `db:"enabled" json:"enabled"`
+	CreatedAt time.Time     `db:"created_at" json:"created_at"`
+}
+
+// ChannelConfig настройки канала, заполняется только поле, соответствующее типу канала
+type ChannelConfig struct {
+	Webhook *WebhookConfig `json:"webhook,omitempty"`
+	Email   *EmailConfig   `json:"email,omitempty"`
+}
+
+type WebhookConfig struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+const (
+	// EmailSecurityStartTLS переключает соединение на TLS командой STARTTLS
+	EmailSecurityStartTLS = "starttls"
+	// EmailSecurityTLS устанавливает TLS соединение сразу
+	EmailSecurityTLS  = "tls"
+	EmailSecurityNone = "none"
+)
+
+type EmailConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Security string   `json:"security"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
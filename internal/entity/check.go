@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+type Check struct {
+	ID              int64         `db:"id" json:"id"`
+	WebsiteID       int64         `db:"website_id" json:"website_id"`
+	CheckedAt       time.Time     `db:"checked_at" json:"checked_at"`
+	AccessTime      time.Duration `db:"access_time" json:"access_time"`
+	StatusCode      int           `db:"status_code" json:"status_code"`
+	Scheme          string        `db:"scheme" json:"scheme"`
+	Available       bool          `db:"available" json:"available"`
+	FailedAssertion string        `db:"failed_assertion" json:"failed_assertion"`
+	Redirects       []Hop         `db:"redirects" json:"redirects"`
+	Failure
+	Phases
+	Certificate
+}
+
+type Stats struct {
+	Count        int           `db:"count" json:"count"`
+	SuccessCount int           `db:"success_count" json:"success_count"`
+	Min          time.Duration `db:"min" json:"min"`
+	Max          time.Duration `db:"max" json:"max"`
+	Mean         time.Duration `db:"mean" json:"mean"`
+	P50          time.Duration `db:"p50" json:"p50"`
+	P90          time.Duration `db:"p90" json:"p90"`
+	P99          time.Duration `db:"p99" json:"p99"`
+}
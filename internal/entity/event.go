@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type EventType string
+
+const (
+	EventStateChanged        EventType = "state_changed"
+	EventCertificateExpiring EventType = "certificate_expiring"
+	// EventTest отправляется при проверке канала через админку
+	EventTest EventType = "test"
+)
+
+// Event событие, о котором отправляется уведомление
+type Event struct {
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Website    Website   `json:"website"`
+	From       State     `json:"from"`
+	To         State     `json:"to"`
+	Check      Check     `json:"check"`
+	// Incident закрытый инцидент, если сайт восстановился после down
+	Incident *Incident `json:"incident"`
+}
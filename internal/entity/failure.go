@@ -0,0 +1,19 @@
+package entity
+
+// ErrorClass причина, по которой проверка сайта не прошла
+type ErrorClass string
+
+const (
+	ErrorClassDNS       ErrorClass = "dns"
+	ErrorClassConnect   ErrorClass = "connect"
+	ErrorClassTLS       ErrorClass = "tls"
+	ErrorClassTimeout   ErrorClass = "timeout"
+	ErrorClassHTTP      ErrorClass = "http"
+	ErrorClassAssertion ErrorClass = "assertion"
+)
+
+// Failure описание ошибки проверки, пустое для успешных проверок
+type Failure struct {
+	ErrorClass   ErrorClass `db:"error_class" json:"error_class"`
+	ErrorMessage string     `db:"error_message" json:"error_message"`
+}
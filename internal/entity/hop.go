@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// Hop один запрос в цепочке редиректов
+type Hop struct {
+	URL        string        `json:"url"`
+	StatusCode int           `json:"status_code"`
+	Latency    time.Duration `json:"latency"`
+}
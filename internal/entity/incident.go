@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type Incident struct {
+	ID           int64      `db:"id" json:"id"`
+	WebsiteID    int64      `db:"website_id" json:"website_id"`
+	URL          string     `db:"url" json:"url"`
+	StartedAt    time.Time  `db:"started_at" json:"started_at"`
+	EndedAt      *time.Time `db:"ended_at" json:"ended_at"`
+	CauseClass   ErrorClass `db:"cause_class" json:"cause_class"`
+	CauseMessage string     `db:"cause_message" json:"cause_message"`
+}
+
+// IncidentFilter отбирает инциденты, пересекающиеся с периодом [From, To],
+// нулевой WebsiteID означает все сайты
+type IncidentFilter struct {
+	WebsiteID int64
+	From      time.Time
+	To        time.Time
+}
@@ -1,8 +1,59 @@
 package entity
 
+import "time"
+
+// LatencyBuckets верхние границы интервалов, по которым распределяется время обработки запросов
+var LatencyBuckets = []time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// Request обработанный запрос к API, учитываемый в метриках
+type Request struct {
+	Method   string
+	Route    string
+	Status   int
+	Duration time.Duration
+}
+
+// MetricsFilter период, за который выбираются метрики, и шаг временного ряда
+type MetricsFilter struct {
+	From        time.Time
+	To          time.Time
+	Granularity time.Duration
+}
+
 type Metrics []Metric
 
+// Metric метрики запросов к одному маршруту с одним методом и классом статуса ответа
 type Metric struct {
-	Endpoint string `json:"endpoint"`
-	Count    int    `json:"count"`
+	Endpoint    string          `json:"endpoint"`
+	Method      string          `json:"method"`
+	StatusClass string          `json:"status_class"`
+	Count       int             `json:"count"`
+	LatencySum  time.Duration   `json:"latency_sum"`
+	Buckets     []LatencyBucket `json:"buckets"`
+	Points      []MetricPoint   `json:"points"`
+}
+
+// MetricPoint количество запросов и суммарное время их обработки за интервал, начинающийся в Time
+type MetricPoint struct {
+	Time       time.Time     `json:"time"`
+	Count      int           `json:"count"`
+	LatencySum time.Duration `json:"latency_sum"`
+}
+
+// LatencyBucket количество запросов, обработанных не дольше Le, нулевой Le соответствует +Inf
+type LatencyBucket struct {
+	Le    time.Duration `json:"le"`
+	Count int           `json:"count"`
 }
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// Phases время, затраченное на каждый этап запроса
+type Phases struct {
+	DNS      time.Duration `db:"dns_time" json:"dns"`
+	Connect  time.Duration `db:"connect_time" json:"connect"`
+	TLS      time.Duration `db:"tls_time" json:"tls"`
+	TTFB     time.Duration `db:"ttfb_time" json:"ttfb"`
+	Transfer time.Duration `db:"transfer_time" json:"transfer"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+// Result обновление сайта после проверки, ID растет монотонно
+// и позволяет подписчику продолжить поток с последнего полученного события
+type Result struct {
+	ID      uint64
+	Website Website
+	Check   Check
+}
+
+// ResultFilter отбирает результаты по адресам сайтов, пустой список означает все сайты,
+// результаты из истории отдаются только с ID больше After
+type ResultFilter struct {
+	URLs  []string
+	After uint64
+}
+
+func (filter ResultFilter) Match(result Result) bool {
+	if len(filter.URLs) == 0 {
+		return true
+	}
+
+	for _, url := range filter.URLs {
+		if url == result.Website.URL {
+			return true
+		}
+	}
+
+	return false
+}
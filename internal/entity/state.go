@@ -0,0 +1,31 @@
+package entity
+
+import "time"
+
+type State string
+
+const (
+	// StateUnknown сайт еще не проверялся
+	StateUnknown  State = "unknown"
+	StateUp       State = "up"
+	StateDegraded State = "degraded"
+	StateDown     State = "down"
+)
+
+// Health состояние сайта с учетом последовательных результатов проверок
+type Health struct {
+	State                State     `db:"state" json:"state"`
+	ConsecutiveFailures  int       `db:"consecutive_failures" json:"consecutive_failures"`
+	ConsecutiveSuccesses int       `db:"consecutive_successes" json:"consecutive_successes"`
+	StateChangedAt       time.Time `db:"state_changed_at" json:"state_changed_at"`
+	// FailingSince время первой из последовательных неудачных проверок, nil если последняя проверка успешна
+	FailingSince *time.Time `db:"failing_since" json:"failing_since"`
+}
+
+// Transition переход сайта из одного состояния в другое по результату проверки
+type Transition struct {
+	Website Website `json:"website"`
+	From    State   `json:"from"`
+	To      State   `json:"to"`
+	Check   Check   `json:"check"`
+}
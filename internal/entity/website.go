@@ -2,9 +2,108 @@ package entity
 
 import "time"
 
+const (
+	SchemeHTTPS = "https"
+	SchemeHTTP  = "http"
+	// SchemeAuto сначала проверяет сайт по https, при ошибке соединения - по http
+	SchemeAuto = "auto"
+)
+
 type Website struct {
-	URL         string        `db:"url" json:"url"`
-	LastCheckAt time.Time     `db:"last_check_at" json:"last_check_at"`
-	AccessTime  time.Duration `db:"access_time" json:"access_time"`
-	StatusCode  int           `db:"status_code" json:"status_code"`
+	ID                    int64         `db:"id" json:"id"`
+	URL                   string        `db:"url" json:"url"`
+	LastCheckAt           time.Time     `db:"last_check_at" json:"last_check_at"`
+	AccessTime            time.Duration `db:"access_time" json:"access_time"`
+	StatusCode            int           `db:"status_code" json:"status_code"`
+	Available             bool          `db:"available" json:"available"`
+	CheckedScheme         string        `db:"checked_scheme" json:"checked_scheme"`
+	CertExpiresAt         *time.Time    `db:"cert_expires_at" json:"cert_expires_at"`
+	CertNotifiedExpiresAt *time.Time    `db:"cert_notified_expires_at" json:"cert_notified_expires_at"`
+	Failure
+	Health
+	Phases
+	CheckConfig
+}
+
+// CheckConfig настройки запроса, которым проверяется сайт
+type CheckConfig struct {
+	Scheme           string            `db:"scheme" json:"scheme"`
+	Method           string            `db:"method" json:"method"`
+	Headers          map[string]string `db:"headers" json:"headers"`
+	Body             string            `db:"body" json:"body"`
+	AcceptedStatuses []StatusRange     `db:"accepted_statuses" json:"accepted_statuses"`
+	Timeout          time.Duration     `db:"timeout" json:"timeout"`
+	FollowRedirects  bool              `db:"follow_redirects" json:"follow_redirects"`
+	MaxRedirects     int               `db:"max_redirects" json:"max_redirects"`
+	Assertions       []Assertion       `db:"assertions" json:"assertions"`
+}
+
+// StatusRange диапазон кодов ответа, при которых сайт считается доступным
+type StatusRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+type WebsiteSort string
+
+const (
+	WebsiteSortURL         WebsiteSort = "url"
+	WebsiteSortAccessTime  WebsiteSort = "access_time"
+	WebsiteSortLastCheckAt WebsiteSort = "last_check_at"
+)
+
+// WebsiteCursor позиция последнего сайта страницы, следующая страница начинается после него
+// в порядке сортировки, при равных значениях порядок определяет ID
+type WebsiteCursor struct {
+	URL         string
+	AccessTime  time.Duration
+	LastCheckAt time.Time
+	ID          int64
+}
+
+func NewWebsiteCursor(website Website) WebsiteCursor {
+	return WebsiteCursor{
+		URL:         website.URL,
+		AccessTime:  website.AccessTime,
+		LastCheckAt: website.LastCheckAt,
+		ID:          website.ID,
+	}
+}
+
+// WebsiteFilter отбирает страницу сайтов, нулевые значения фильтров не ограничивают выборку
+type WebsiteFilter struct {
+	StatusCode    int
+	Available     *bool
+	URLPrefix     string
+	CheckedBefore time.Time
+	Sort          WebsiteSort
+	Desc          bool
+	After         *WebsiteCursor
+	Limit         int
+}
+
+type WebsitePage struct {
+	Websites []Website
+	Next     *WebsiteCursor
+}
+
+// Estimate результат оценки одного адреса из пакетного запроса, Err - ошибка для этого адреса
+type Estimate struct {
+	URL     string
+	Website Website
+	Err     error
+}
+
+// TopFilter отбирает сайты для рейтинга по времени доступа, Pattern - шаблон адреса с * вместо любой подстроки
+type TopFilter struct {
+	Desc       bool
+	Limit      int
+	TLD        string
+	Pattern    string
+	StatusCode int
+}
+
+type RankedWebsite struct {
+	Rank int `db:"rank"`
+	Website
 }
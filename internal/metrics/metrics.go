@@ -0,0 +1,143 @@
+package metrics
+
+import (
+	"estimate/internal/entity"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const namespace = "estimate"
+
+// Recorder собирает метрики сервиса и проверок в формате Prometheus
+type Recorder interface {
+	Handler() http.Handler
+	ObserveRequest(method string, route string, status int, duration time.Duration)
+	ObserveWatch(duration time.Duration)
+	ObserveCheck(check entity.Check)
+	SetWebsites(websites []entity.Website)
+}
+
+type recorder struct {
+	registry          *prometheus.Registry
+	requests          *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+	watchDuration     prometheus.Histogram
+	checks            *prometheus.CounterVec
+	checkFailures     *prometheus.CounterVec
+	websiteAccessTime *prometheus.GaugeVec
+	websiteStatusCode *prometheus.GaugeVec
+	websiteUp         *prometheus.GaugeVec
+}
+
+func New() Recorder {
+	recorder := &recorder{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Number of HTTP requests by route, method and status.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Duration of HTTP requests by route, method and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		watchDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "watch_duration_seconds",
+			Help:      "Duration of a full check cycle over all websites.",
+			Buckets:   prometheus.ExponentialBuckets(0.5, 2, 10),
+		}),
+		checks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "checks_total",
+			Help:      "Number of performed website checks by scheme.",
+		}, []string{"scheme"}),
+		checkFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "check_failures_total",
+			Help:      "Number of failed website checks by error class.",
+		}, []string{"error_class"}),
+		websiteAccessTime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "website_access_time_seconds",
+			Help:      "Access time of the last successful check.",
+		}, []string{"url"}),
+		websiteStatusCode: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "website_status_code",
+			Help:      "Status code of the last check, 0 if the request failed.",
+		}, []string{"url"}),
+		websiteUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "website_up",
+			Help:      "Whether the last check of the website passed.",
+		}, []string{"url"}),
+	}
+
+	recorder.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		recorder.requests,
+		recorder.requestDuration,
+		recorder.watchDuration,
+		recorder.checks,
+		recorder.checkFailures,
+		recorder.websiteAccessTime,
+		recorder.websiteStatusCode,
+		recorder.websiteUp,
+	)
+
+	return recorder
+}
+
+func (recorder *recorder) Handler() http.Handler {
+	return promhttp.HandlerFor(recorder.registry, promhttp.HandlerOpts{})
+}
+
+func (recorder *recorder) ObserveRequest(method string, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+
+	recorder.requests.WithLabelValues(method, route, code).Inc()
+	recorder.requestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+func (recorder *recorder) ObserveWatch(duration time.Duration) {
+	recorder.watchDuration.Observe(duration.Seconds())
+}
+
+func (recorder *recorder) ObserveCheck(check entity.Check) {
+	recorder.checks.WithLabelValues(check.Scheme).Inc()
+
+	if !check.Available {
+		recorder.checkFailures.WithLabelValues(string(check.ErrorClass)).Inc()
+	}
+}
+
+// SetWebsites заменяет значения метрик по сайтам, чтобы удаленные сайты пропадали из выдачи,
+// еще не проверявшиеся сайты в выдачу не попадают
+func (recorder *recorder) SetWebsites(websites []entity.Website) {
+	recorder.websiteAccessTime.Reset()
+	recorder.websiteStatusCode.Reset()
+	recorder.websiteUp.Reset()
+
+	for _, website := range websites {
+		if website.State == entity.StateUnknown {
+			continue
+		}
+
+		recorder.websiteAccessTime.WithLabelValues(website.URL).Set(website.AccessTime.Seconds())
+		recorder.websiteStatusCode.WithLabelValues(website.URL).Set(float64(website.StatusCode))
+
+		var up float64
+		if website.Available {
+			up = 1
+		}
+		recorder.websiteUp.WithLabelValues(website.URL).Set(up)
+	}
+}
@@ -0,0 +1,210 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/pkg/retry"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const emailTimeout = 30 * time.Second
+
+// emailSender отправляет письма через SMTP, rootCAs - корневые сертификаты для проверки сервера,
+// при nil используются системные
+type emailSender struct {
+	rootCAs *x509.CertPool
+}
+
+func newEmailSender() *emailSender {
+	return &emailSender{}
+}
+
+// send отправляет письмо по шаблону события, ошибки сервера 5xx не повторяются
+func (sender *emailSender) send(ctx context.Context, channel entity.Channel, event entity.Event) error {
+	config := channel.Config.Email
+	if config == nil {
+		return retry.Stop(errors.New("email config is empty"))
+	}
+
+	name := templateName(event)
+	if name == "" {
+		return nil
+	}
+
+	message, err := newMessage(*config, emailTemplates[name], newTemplateData(event))
+	if err != nil {
+		return retry.Stop(err)
+	}
+
+	err = sender.deliver(ctx, *config, message)
+	if err != nil {
+		var protoErr *textproto.Error
+		if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+			return retry.Stop(err)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (sender *emailSender) deliver(ctx context.Context, config entity.EmailConfig, message []byte) error {
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return retry.Stop(err)
+	}
+
+	dialer := net.Dialer{Timeout: emailTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(config.Host, strconv.Itoa(config.Port)))
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(emailTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	if config.Security == entity.EmailSecurityTLS {
+		conn = tls.Client(conn, sender.tlsConfig(config.Host))
+	}
+
+	client, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if config.Security == entity.EmailSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return retry.Stop(errors.New("smtp server does not support STARTTLS"))
+		}
+
+		err = client.StartTLS(sender.tlsConfig(config.Host))
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.Username != "" {
+		err = client.Auth(smtp.PlainAuth("", config.Username, config.Password, config.Host))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(from.Address)
+	if err != nil {
+		return err
+	}
+
+	for _, rawAddress := range config.To {
+		var to *mail.Address
+		to, err = mail.ParseAddress(rawAddress)
+		if err != nil {
+			return retry.Stop(err)
+		}
+
+		err = client.Rcpt(to.Address)
+		if err != nil {
+			return err
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(message)
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (sender *emailSender) tlsConfig(host string) *tls.Config {
+	return &tls.Config{
+		ServerName: host,
+		RootCAs:    sender.rootCAs,
+	}
+}
+
+// newMessage собирает письмо multipart/alternative из текстовой и html версий
+func newMessage(config entity.EmailConfig, template emailTemplate, data templateData) ([]byte, error) {
+	subject, text, html, err := template.render(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{contentType: "text/plain; charset=utf-8", content: text},
+		{contentType: "text/html; charset=utf-8", content: html},
+	}
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := quotedprintable.NewWriter(partWriter)
+		_, err = encoder.Write([]byte(part.content))
+		if err != nil {
+			return nil, err
+		}
+
+		err = encoder.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(config.To, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%s\r\n", writer.Boundary())
+	fmt.Fprintf(&message, "\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
@@ -0,0 +1,351 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"estimate/internal/entity"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpMessage письмо, принятое тестовым SMTP сервером
+type smtpMessage struct {
+	TLS  bool
+	Auth string
+	From string
+	To   []string
+	Data string
+}
+
+// smtpServer тестовый SMTP сервер, принимает одно соединение,
+// при tlsConfig == nil не поддерживает STARTTLS
+type smtpServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	messages  chan smtpMessage
+}
+
+func newSMTPServer(t *testing.T, tlsConfig *tls.Config) *smtpServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &smtpServer{
+		listener:  listener,
+		tlsConfig: tlsConfig,
+		messages:  make(chan smtpMessage, 1),
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go server.serve()
+
+	return server
+}
+
+func (server *smtpServer) config(security string) entity.EmailConfig {
+	host, rawPort, _ := net.SplitHostPort(server.listener.Addr().String())
+	port, _ := strconv.Atoi(rawPort)
+
+	return entity.EmailConfig{
+		Host:     host,
+		Port:     port,
+		Security: security,
+		From:     "Estimate <estimate@example.com>",
+		To:       []string{"oncall@example.com", "ops@example.com"},
+	}
+}
+
+func (server *smtpServer) serve() {
+	conn, err := server.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 localhost ESMTP")
+
+	var message smtpMessage
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command, argument, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(command) {
+		case "EHLO", "HELO":
+			_ = text.PrintfLine("250-localhost")
+			if server.tlsConfig != nil && !message.TLS {
+				_ = text.PrintfLine("250-STARTTLS")
+			}
+			_ = text.PrintfLine("250 AUTH PLAIN")
+		case "STARTTLS":
+			_ = text.PrintfLine("220 ready to start TLS")
+
+			tlsConn := tls.Server(conn, server.tlsConfig)
+			err = tlsConn.Handshake()
+			if err != nil {
+				return
+			}
+
+			conn = tlsConn
+			text = textproto.NewConn(conn)
+			message.TLS = true
+		case "AUTH":
+			message.Auth = argument
+			_ = text.PrintfLine("235 authenticated")
+		case "MAIL":
+			message.From = argument
+			_ = text.PrintfLine("250 ok")
+		case "RCPT":
+			message.To = append(message.To, argument)
+			_ = text.PrintfLine("250 ok")
+		case "DATA":
+			_ = text.PrintfLine("354 end data with <CR><LF>.<CR><LF>")
+
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			message.Data = string(data)
+			_ = text.PrintfLine("250 queued")
+		case "QUIT":
+			_ = text.PrintfLine("221 bye")
+			server.messages <- message
+			return
+		default:
+			_ = text.PrintfLine("502 command not implemented")
+		}
+	}
+}
+
+// testTLS возвращает настройки TLS сервера с сертификатом для 127.0.0.1 и пул, которому этот сертификат доверен
+func testTLS(t *testing.T) (*tls.Config, *x509.CertPool) {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.StartTLS()
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	return &tls.Config{Certificates: server.TLS.Certificates}, pool
+}
+
+func TestEmailWithoutEncryption(t *testing.T) {
+	server := newSMTPServer(t, nil)
+
+	err := newEmailSender().send(context.Background(), entity.Channel{
+		Type:   entity.ChannelEmail,
+		Config: entity.ChannelConfig{Email: ptr(server.config(entity.EmailSecurityNone))},
+	}, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := <-server.messages
+	if message.TLS {
+		t.Error("message must be sent without TLS")
+	}
+
+	if message.Auth != "" {
+		t.Errorf("auth = %q, want none without username", message.Auth)
+	}
+
+	if message.From != "FROM:<estimate@example.com>" {
+		t.Errorf("mail from = %q", message.From)
+	}
+
+	wantTo := []string{"TO:<oncall@example.com>", "TO:<ops@example.com>"}
+	if strings.Join(message.To, ",") != strings.Join(wantTo, ",") {
+		t.Errorf("rcpt to = %v, want %v", message.To, wantTo)
+	}
+
+	parts := readMultipart(t, message.Data, "example.com is down")
+	if !strings.Contains(parts["text/plain; charset=utf-8"], "Previous state: degraded") {
+		t.Errorf("text part = %q", parts["text/plain; charset=utf-8"])
+	}
+
+	if !strings.Contains(parts["text/html; charset=utf-8"], `<h2 style="color: #c0392b;">example.com is down</h2>`) {
+		t.Errorf("html part = %q", parts["text/html; charset=utf-8"])
+	}
+}
+
+func TestEmailStartTLS(t *testing.T) {
+	serverTLS, pool := testTLS(t)
+	server := newSMTPServer(t, serverTLS)
+
+	config := server.config(entity.EmailSecurityStartTLS)
+	config.Username = "estimate"
+	config.Password = "s3cr3t"
+
+	sender := &emailSender{rootCAs: pool}
+	err := sender.send(context.Background(), entity.Channel{
+		Type:   entity.ChannelEmail,
+		Config: entity.ChannelConfig{Email: &config},
+	}, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	message := <-server.messages
+	if !message.TLS {
+		t.Fatal("message must be sent after STARTTLS")
+	}
+
+	mechanism, credentials, _ := strings.Cut(message.Auth, " ")
+	decoded, _ := base64.StdEncoding.DecodeString(credentials)
+	if mechanism != "PLAIN" || string(decoded) != "\x00estimate\x00s3cr3t" {
+		t.Errorf("auth = %q %q", mechanism, decoded)
+	}
+
+	readMultipart(t, message.Data, "example.com is down")
+}
+
+func TestEmailStartTLSNotSupported(t *testing.T) {
+	server := newSMTPServer(t, nil)
+
+	err := newEmailSender().send(context.Background(), entity.Channel{
+		Type:   entity.ChannelEmail,
+		Config: entity.ChannelConfig{Email: ptr(server.config(entity.EmailSecurityStartTLS))},
+	}, testEvent())
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Fatalf("err = %v, want STARTTLS error", err)
+	}
+
+	// окончательная ошибка обернута retry.Stop, обычные ошибки отправки не обернуты
+	var stop interface{ Unwrap() error }
+	if !errors.As(err, &stop) {
+		t.Error("missing STARTTLS must not be retried")
+	}
+}
+
+func TestEmailRecoveredOnlyAfterDown(t *testing.T) {
+	startedAt := time.Date(2023, 6, 23, 12, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(10 * time.Minute)
+	incident := &entity.Incident{WebsiteID: 51, StartedAt: startedAt, EndedAt: &endedAt}
+
+	tests := []struct {
+		name     string
+		from     entity.State
+		incident *entity.Incident
+		want     string
+	}{
+		{name: "down", from: entity.StateDown, incident: incident, want: templateRecovered},
+		{name: "degraded after down", from: entity.StateDegraded, incident: incident, want: templateRecovered},
+		{name: "degraded without incident", from: entity.StateDegraded, want: ""},
+		{name: "unknown", from: entity.StateUnknown, want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := testEvent()
+			event.From = test.from
+			event.To = entity.StateUp
+			event.Incident = test.incident
+
+			if got := templateName(event); got != test.want {
+				t.Errorf("template = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEmailRecoveredAfterDegraded(t *testing.T) {
+	server := newSMTPServer(t, nil)
+
+	startedAt := time.Date(2023, 6, 23, 12, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(10 * time.Minute)
+
+	event := testEvent()
+	event.From = entity.StateDegraded
+	event.To = entity.StateUp
+	event.Incident = &entity.Incident{WebsiteID: 51, StartedAt: startedAt, EndedAt: &endedAt}
+
+	err := newEmailSender().send(context.Background(), entity.Channel{
+		Type:   entity.ChannelEmail,
+		Config: entity.ChannelConfig{Email: ptr(server.config(entity.EmailSecurityNone))},
+	}, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := readMultipart(t, (<-server.messages).Data, "example.com is back up")
+	if !strings.Contains(parts["text/plain; charset=utf-8"], "Down since:     2023-06-23 12:00:00 UTC") {
+		t.Errorf("text part = %q", parts["text/plain; charset=utf-8"])
+	}
+}
+
+// readMultipart проверяет заголовки письма и возвращает декодированные части по Content-Type
+func readMultipart(t *testing.T, data string, subject string) map[string]string {
+	t.Helper()
+
+	message, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotSubject, err := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if err != nil || gotSubject != subject {
+		t.Errorf("subject = %q, want %q", gotSubject, subject)
+	}
+
+	if got := message.Header.Get("To"); got != "oncall@example.com, ops@example.com" {
+		t.Errorf("to header = %q", got)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q", message.Header.Get("Content-Type"))
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parts[part.Header.Get("Content-Type")] = string(content)
+	}
+
+	if len(parts) != 2 {
+		t.Errorf("parts = %v, want text and html", parts)
+	}
+
+	return parts
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,44 @@
+package notifier
+
+import (
+	"context"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"estimate/pkg/retry"
+)
+
+// Notifier отправляет событие в канал, повторяя попытку при временных ошибках
+type Notifier interface {
+	Send(ctx context.Context, channel entity.Channel, event entity.Event) error
+}
+
+// sender отправляет событие в канал определенного типа
+type sender interface {
+	send(ctx context.Context, channel entity.Channel, event entity.Event) error
+}
+
+type notifier struct {
+	senders map[entity.ChannelType]sender
+	retry   retry.Config
+}
+
+func New(retryConfig retry.Config) Notifier {
+	return &notifier{
+		senders: map[entity.ChannelType]sender{
+			entity.ChannelWebhook: newWebhookSender(),
+			entity.ChannelEmail:   newEmailSender(),
+		},
+		retry: retryConfig,
+	}
+}
+
+func (notifier *notifier) Send(ctx context.Context, channel entity.Channel, event entity.Event) error {
+	sender, ok := notifier.senders[channel.Type]
+	if !ok {
+		return apperror.BadRequest.WithMessage("unknown channel type " + string(channel.Type))
+	}
+
+	return retry.Do(ctx, notifier.retry, func(ctx context.Context) error {
+		return sender.send(ctx, channel, event)
+	})
+}
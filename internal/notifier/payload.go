@@ -0,0 +1,86 @@
+package notifier
+
+import (
+	"estimate/internal/entity"
+	"time"
+)
+
+type payload struct {
+	Type        entity.EventType    `json:"type"`
+	OccurredAt  time.Time           `json:"occurred_at"`
+	Website     websitePayload      `json:"website"`
+	From        entity.State        `json:"from,omitempty"`
+	To          entity.State        `json:"to,omitempty"`
+	Check       checkPayload        `json:"check"`
+	Certificate *certificatePayload `json:"certificate,omitempty"`
+	Incident    *incidentPayload    `json:"incident,omitempty"`
+}
+
+type websitePayload struct {
+	ID    int64        `json:"id"`
+	URL   string       `json:"url"`
+	State entity.State `json:"state"`
+}
+
+type checkPayload struct {
+	CheckedAt       time.Time `json:"checked_at"`
+	StatusCode      int       `json:"status_code"`
+	Scheme          string    `json:"scheme"`
+	Available       bool      `json:"available"`
+	AccessTime      string    `json:"access_time"`
+	ErrorClass      string    `json:"error_class,omitempty"`
+	ErrorMessage    string    `json:"error_message,omitempty"`
+	FailedAssertion string    `json:"failed_assertion,omitempty"`
+}
+
+type incidentPayload struct {
+	ID        int64      `json:"id"`
+	StartedAt time.Time  `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+type certificatePayload struct {
+	ExpiresAt time.Time `json:"expires_at"`
+	Issuer    string    `json:"issuer"`
+}
+
+func newPayload(event entity.Event) payload {
+	result := payload{
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Website: websitePayload{
+			ID:    event.Website.ID,
+			URL:   event.Website.URL,
+			State: event.Website.State,
+		},
+		From: event.From,
+		To:   event.To,
+		Check: checkPayload{
+			CheckedAt:       event.Check.CheckedAt,
+			StatusCode:      event.Check.StatusCode,
+			Scheme:          event.Check.Scheme,
+			Available:       event.Check.Available,
+			AccessTime:      event.Check.AccessTime.String(),
+			ErrorClass:      string(event.Check.ErrorClass),
+			ErrorMessage:    event.Check.ErrorMessage,
+			FailedAssertion: event.Check.FailedAssertion,
+		},
+	}
+
+	if event.Type == entity.EventCertificateExpiring && event.Website.CertExpiresAt != nil {
+		result.Certificate = &certificatePayload{
+			ExpiresAt: *event.Website.CertExpiresAt,
+			Issuer:    event.Check.Issuer,
+		}
+	}
+
+	if event.Incident != nil {
+		result.Incident = &incidentPayload{
+			ID:        event.Incident.ID,
+			StartedAt: event.Incident.StartedAt,
+			EndedAt:   event.Incident.EndedAt,
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+package notifier
+
+import (
+	"bytes"
+	"embed"
+	"estimate/internal/entity"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+const (
+	templateDown                = "down"
+	templateRecovered           = "recovered"
+	templateCertificateExpiring = "certificate_expiring"
+)
+
+// emailTemplate шаблоны письма, тема задается блоком subject в текстовом шаблоне
+type emailTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+var emailTemplates = map[string]emailTemplate{
+	templateDown:                mustParseEmailTemplate(templateDown),
+	templateRecovered:           mustParseEmailTemplate(templateRecovered),
+	templateCertificateExpiring: mustParseEmailTemplate(templateCertificateExpiring),
+}
+
+func mustParseEmailTemplate(name string) emailTemplate {
+	return emailTemplate{
+		text: texttemplate.Must(texttemplate.ParseFS(templatesFS, "templates/"+name+".txt")),
+		html: htmltemplate.Must(htmltemplate.ParseFS(templatesFS, "templates/"+name+".html")),
+	}
+}
+
+// templateData данные события, доступные в шаблонах
+type templateData struct {
+	Test         bool
+	URL          string
+	OccurredAt   time.Time
+	From         entity.State
+	To           entity.State
+	CheckedAt    time.Time
+	StatusCode   int
+	AccessTime   time.Duration
+	ErrorClass   entity.ErrorClass
+	ErrorMessage string
+	ExpiresAt    time.Time
+	DaysLeft     int
+	Issuer       string
+	DownSince    time.Time
+}
+
+func newTemplateData(event entity.Event) templateData {
+	data := templateData{
+		Test:         event.Type == entity.EventTest,
+		URL:          event.Website.URL,
+		OccurredAt:   event.OccurredAt,
+		From:         event.From,
+		To:           event.To,
+		CheckedAt:    event.Check.CheckedAt,
+		StatusCode:   event.Check.StatusCode,
+		AccessTime:   event.Check.AccessTime,
+		ErrorClass:   event.Check.ErrorClass,
+		ErrorMessage: event.Check.ErrorMessage,
+		Issuer:       event.Check.Issuer,
+	}
+
+	if event.Incident != nil {
+		data.DownSince = event.Incident.StartedAt
+	}
+
+	if event.Website.CertExpiresAt != nil {
+		data.ExpiresAt = *event.Website.CertExpiresAt
+		data.DaysLeft = int(data.ExpiresAt.Sub(event.OccurredAt).Hours() / 24)
+	}
+
+	return data
+}
+
+// templateName выбирает шаблон письма по событию, для остальных событий письмо не отправляется,
+// письмо о восстановлении отправляется только при закрытии инцидента, то есть после down,
+// переход в up без инцидента восстановлением не считается
+func templateName(event entity.Event) string {
+	switch event.Type {
+	case entity.EventStateChanged, entity.EventTest:
+		switch {
+		case event.To == entity.StateDown:
+			return templateDown
+		case event.To == entity.StateUp && event.Incident != nil:
+			return templateRecovered
+		}
+	case entity.EventCertificateExpiring:
+		return templateCertificateExpiring
+	}
+
+	return ""
+}
+
+// render возвращает тему, текстовую и html версии письма
+func (template emailTemplate) render(data templateData) (subject string, text string, html string, err error) {
+	var buf bytes.Buffer
+
+	err = template.text.ExecuteTemplate(&buf, "subject", data)
+	if err != nil {
+		return "", "", "", err
+	}
+	subject = buf.String()
+
+	buf.Reset()
+	err = template.text.Execute(&buf, data)
+	if err != nil {
+		return "", "", "", err
+	}
+	text = buf.String()
+
+	buf.Reset()
+	err = template.html.Execute(&buf, data)
+	if err != nil {
+		return "", "", "", err
+	}
+	html = buf.String()
+
+	return subject, text, html, nil
+}
@@ -0,0 +1,84 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/pkg/retry"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	SignatureHeader = "X-Estimate-Signature"
+	EventHeader     = "X-Estimate-Event"
+
+	webhookTimeout = 10 * time.Second
+)
+
+type webhookSender struct {
+	client *http.Client
+}
+
+func newWebhookSender() *webhookSender {
+	return &webhookSender{
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// send отправляет событие POST запросом, ошибки 4xx не повторяются
+func (sender *webhookSender) send(ctx context.Context, channel entity.Channel, event entity.Event) error {
+	config := channel.Config.Webhook
+	if config == nil {
+		return retry.Stop(errors.New("webhook config is empty"))
+	}
+
+	body, err := json.Marshal(newPayload(event))
+	if err != nil {
+		return retry.Stop(err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return retry.Stop(err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(EventHeader, string(event.Type))
+	if config.Secret != "" {
+		request.Header.Set(SignatureHeader, Signature(config.Secret, body))
+	}
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 1<<20))
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return nil
+	}
+
+	err = fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	if response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+
+	return retry.Stop(err)
+}
+
+// Signature возвращает подпись тела запроса в формате sha256=<hex>
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
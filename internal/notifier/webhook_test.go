@@ -0,0 +1,155 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"estimate/internal/entity"
+	"estimate/pkg/retry"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testEvent() entity.Event {
+	website := entity.Website{ID: 1, URL: "example.com"}
+	website.State = entity.StateDown
+
+	check := entity.Check{
+		CheckedAt:  time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC),
+		StatusCode: http.StatusBadGateway,
+		Scheme:     entity.SchemeHTTPS,
+	}
+	check.ErrorClass = entity.ErrorClassHTTP
+	check.ErrorMessage = "unexpected status code 502"
+
+	return entity.Event{
+		Type:       entity.EventStateChanged,
+		OccurredAt: time.Date(2023, 7, 1, 12, 0, 1, 0, time.UTC),
+		Website:    website,
+		From:       entity.StateDegraded,
+		To:         entity.StateDown,
+		Check:      check,
+	}
+}
+
+func testRetryConfig() retry.Config {
+	return retry.Config{
+		Attempts:        3,
+		InitialInterval: time.Millisecond,
+		MaxInterval:     time.Millisecond,
+	}
+}
+
+func TestWebhookSignedPayload(t *testing.T) {
+	const secret = "webhook secret"
+
+	var received []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		received = body
+
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+
+		if got := r.Header.Get("Content-Type"); got != "application/json" {
+			t.Errorf("content type = %q, want application/json", got)
+		}
+
+		if got := r.Header.Get(EventHeader); got != string(entity.EventStateChanged) {
+			t.Errorf("event header = %q, want %q", got, entity.EventStateChanged)
+		}
+
+		if got, want := r.Header.Get(SignatureHeader), Signature(secret, body); got != want {
+			t.Errorf("signature = %q, want %q", got, want)
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := New(testRetryConfig()).Send(context.Background(), entity.Channel{
+		Type:   entity.ChannelWebhook,
+		Config: entity.ChannelConfig{Webhook: &entity.WebhookConfig{URL: server.URL, Secret: secret}},
+	}, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got payload
+	err = json.Unmarshal(received, &got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Type != entity.EventStateChanged || got.From != entity.StateDegraded || got.To != entity.StateDown {
+		t.Errorf("payload event = %s %s -> %s", got.Type, got.From, got.To)
+	}
+
+	if got.Website.URL != "example.com" || got.Website.State != entity.StateDown {
+		t.Errorf("payload website = %+v", got.Website)
+	}
+
+	if got.Check.StatusCode != http.StatusBadGateway || got.Check.ErrorMessage != "unexpected status code 502" {
+		t.Errorf("payload check = %+v", got.Check)
+	}
+}
+
+func TestWebhookUnsigned(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Header[SignatureHeader]; ok {
+			t.Error("request without secret must not be signed")
+		}
+	}))
+	defer server.Close()
+
+	err := New(testRetryConfig()).Send(context.Background(), entity.Channel{
+		Type:   entity.ChannelWebhook,
+		Config: entity.ChannelConfig{Webhook: &entity.WebhookConfig{URL: server.URL}},
+	}, testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		attempts int32
+		wantErr  bool
+	}{
+		{name: "retries 5xx", statuses: []int{http.StatusServiceUnavailable, http.StatusOK}, attempts: 2},
+		{name: "gives up after attempts", statuses: []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, attempts: 3, wantErr: true},
+		{name: "does not retry 4xx", statuses: []int{http.StatusBadRequest, http.StatusOK}, attempts: 1, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempt := attempts.Add(1)
+				w.WriteHeader(test.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			err := New(testRetryConfig()).Send(context.Background(), entity.Channel{
+				Type:   entity.ChannelWebhook,
+				Config: entity.ChannelConfig{Webhook: &entity.WebhookConfig{URL: server.URL}},
+			}, testEvent())
+			if (err != nil) != test.wantErr {
+				t.Errorf("err = %v, want error %t", err, test.wantErr)
+			}
+
+			if got := attempts.Load(); got != test.attempts {
+				t.Errorf("attempts = %d, want %d", got, test.attempts)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"estimate/internal/entity"
+	"estimate/internal/storage"
+	"estimate/pkg/apperror"
+	"time"
+)
+
+type APIKeyService interface {
+	Issue(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error)
+	Authenticate(ctx context.Context, rawKey string) (entity.APIKey, error)
+	Select(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+}
+
+const (
+	apiKeyPrefix = "est_"
+	// apiKeyPrefixLength длина начала ключа, которое хранится открыто, чтобы ключ можно было узнать в списке
+	apiKeyPrefixLength = 12
+)
+
+type apiKeyService struct {
+	storage storage.APIKeyStorage
+}
+
+func NewAPIKeyService(storage storage.APIKeyStorage) APIKeyService {
+	return &apiKeyService{
+		storage: storage,
+	}
+}
+
+// Issue создает ключ и возвращает его в открытом виде, после этого получить ключ повторно нельзя
+func (service *apiKeyService) Issue(ctx context.Context, name string, scopes []string) (entity.APIKey, string, error) {
+	secret := make([]byte, 24)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return entity.APIKey{}, "", apperror.Internal.WithError(err)
+	}
+
+	rawKey := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	key, err := service.storage.Create(ctx, entity.APIKey{
+		Name:   name,
+		Prefix: rawKey[:apiKeyPrefixLength],
+		Hash:   hashAPIKey(rawKey),
+		Scopes: scopes,
+	})
+	if err != nil {
+		return entity.APIKey{}, "", err
+	}
+
+	return key, rawKey, nil
+}
+
+func (service *apiKeyService) Authenticate(ctx context.Context, rawKey string) (entity.APIKey, error) {
+	key, err := service.storage.GetByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.APIKey{}, apperror.Unauthorized.WithError(apperr).WithMessage("invalid api key")
+		}
+
+		return entity.APIKey{}, err
+	}
+
+	err = service.storage.Touch(ctx, key.ID, time.Now())
+	if err != nil {
+		return entity.APIKey{}, err
+	}
+
+	return key, nil
+}
+
+func (service *apiKeyService) Select(ctx context.Context) ([]entity.APIKey, error) {
+	keys, err := service.storage.Select(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (service *apiKeyService) Revoke(ctx context.Context, id int64) error {
+	err := service.storage.Revoke(ctx, id, time.Now())
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return apperr.WithMessage("api key not found")
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// hashAPIKey ключи генерируются случайно с достаточной энтропией, поэтому медленный хеш не нужен
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"estimate/internal/entity"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxCachedRegexps ограничивает количество шаблонов в кеше, при переполнении кеш очищается,
+// чтобы не копились шаблоны измененных и удаленных сайтов
+const maxCachedRegexps = 1000
+
+// regexps кеширует скомпилированные шаблоны проверок, чтобы не компилировать их при каждой проверке,
+// шаблоны проверяются при сохранении сайта
+var regexps = &regexpCache{patterns: map[string]*regexp.Regexp{}}
+
+type regexpCache struct {
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+func (cache *regexpCache) compile(pattern string) (*regexp.Regexp, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	if re, ok := cache.patterns[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cache.patterns) >= maxCachedRegexps {
+		cache.patterns = map[string]*regexp.Regexp{}
+	}
+	cache.patterns[pattern] = re
+
+	return re, nil
+}
+
+// hasBodyAssertions сообщает, нужно ли сохранять тело ответа для проверок
+func hasBodyAssertions(assertions []entity.Assertion) bool {
+	for _, assertion := range assertions {
+		switch assertion.Type {
+		case entity.AssertionBodyContains, entity.AssertionBodyNotContains,
+			entity.AssertionBodyRegex, entity.AssertionJSONPath:
+			return true
+		}
+	}
+
+	return false
+}
+
+// readBody вычитывает тело ответа и возвращает его первые maxBodySize байт, если они нужны для проверок,
+// и количество прочитанных байт, читается на байт больше наибольшего лимита, чтобы превышение было заметно
+func readBody(reader io.Reader, assertions []entity.Assertion) ([]byte, int64) {
+	limit := int64(maxBodySize)
+	for _, assertion := range assertions {
+		if assertion.Type != entity.AssertionMaxBodySize {
+			continue
+		}
+
+		maxSize, err := strconv.ParseInt(assertion.Value, 10, 64)
+		if err == nil && maxSize > limit {
+			limit = maxSize
+		}
+	}
+	reader = io.LimitReader(reader, limit+1)
+
+	if !hasBodyAssertions(assertions) {
+		size, _ := io.Copy(io.Discard, reader)
+
+		return nil, size
+	}
+
+	var body bytes.Buffer
+	size, _ := io.Copy(&body, io.LimitReader(reader, maxBodySize))
+	rest, _ := io.Copy(io.Discard, reader)
+
+	return body.Bytes(), size + rest
+}
+
+// assert выполняет проверки содержимого ответа и возвращает описание первой не пройденной,
+// пустая строка означает, что все проверки пройдены
+func assert(assertions []entity.Assertion, header http.Header, body []byte, size int64) string {
+	for _, assertion := range assertions {
+		if !assertOne(assertion, header, body, size) {
+			return describeAssertion(assertion)
+		}
+	}
+
+	return ""
+}
+
+func assertOne(assertion entity.Assertion, header http.Header, body []byte, size int64) bool {
+	switch assertion.Type {
+	case entity.AssertionBodyContains:
+		return bytes.Contains(body, []byte(assertion.Value))
+	case entity.AssertionBodyNotContains:
+		return !bytes.Contains(body, []byte(assertion.Value))
+	case entity.AssertionBodyRegex:
+		re, err := regexps.compile(assertion.Value)
+		if err != nil {
+			return false
+		}
+
+		return re.Match(body)
+	case entity.AssertionJSONPath:
+		value, ok := lookupJSONPath(body, assertion.Target)
+		if !ok {
+			return false
+		}
+
+		return value == assertion.Value
+	case entity.AssertionHeaderPresent:
+		_, ok := header[http.CanonicalHeaderKey(assertion.Target)]
+
+		return ok
+	case entity.AssertionHeaderMatches:
+		values, ok := header[http.CanonicalHeaderKey(assertion.Target)]
+		if !ok {
+			return false
+		}
+
+		re, err := regexps.compile(assertion.Value)
+		if err != nil {
+			return false
+		}
+
+		for _, value := range values {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+
+		return false
+	case entity.AssertionMaxBodySize:
+		maxSize, err := strconv.ParseInt(assertion.Value, 10, 64)
+		if err != nil {
+			return false
+		}
+
+		return size <= maxSize
+	}
+
+	return false
+}
+
+func describeAssertion(assertion entity.Assertion) string {
+	description := string(assertion.Type)
+	if assertion.Target != "" {
+		description += " " + assertion.Target
+	}
+
+	if assertion.Value != "" {
+		description += fmt.Sprintf(" %q", assertion.Value)
+	}
+
+	return description
+}
+
+// lookupJSONPath находит значение по пути вида "$.data.items[0].status" или "data.items.0.status",
+// строки возвращаются как есть, остальные значения - в виде JSON
+func lookupJSONPath(body []byte, path string) (string, bool) {
+	var document any
+	err := json.Unmarshal(body, &document)
+	if err != nil {
+		return "", false
+	}
+
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	path = strings.ReplaceAll(strings.ReplaceAll(path, "[", "."), "]", "")
+
+	current := document
+	if path != "" {
+		for _, key := range strings.Split(path, ".") {
+			switch node := current.(type) {
+			case map[string]any:
+				value, ok := node[key]
+				if !ok {
+					return "", false
+				}
+
+				current = value
+			case []any:
+				index, err := strconv.Atoi(key)
+				if err != nil || index < 0 || index >= len(node) {
+					return "", false
+				}
+
+				current = node[index]
+			default:
+				return "", false
+			}
+		}
+	}
+
+	if value, ok := current.(string); ok {
+		return value, true
+	}
+
+	value, err := json.Marshal(current)
+	if err != nil {
+		return "", false
+	}
+
+	return string(value), true
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"bytes"
+	"estimate/internal/entity"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestAssert(t *testing.T) {
+	header := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	body := []byte(`{"status": "ok", "data": {"items": [{"id": 1}, {"id": 2}]}}`)
+
+	tests := []struct {
+		name      string
+		assertion entity.Assertion
+		want      bool
+	}{
+		{name: "body contains", assertion: entity.Assertion{Type: entity.AssertionBodyContains, Value: `"ok"`}, want: true},
+		{name: "body contains missing", assertion: entity.Assertion{Type: entity.AssertionBodyContains, Value: "error"}},
+		{name: "body not contains", assertion: entity.Assertion{Type: entity.AssertionBodyNotContains, Value: "error"}, want: true},
+		{name: "body not contains present", assertion: entity.Assertion{Type: entity.AssertionBodyNotContains, Value: "status"}},
+		{name: "body regex", assertion: entity.Assertion{Type: entity.AssertionBodyRegex, Value: `"id":\s*2`}, want: true},
+		{name: "body regex no match", assertion: entity.Assertion{Type: entity.AssertionBodyRegex, Value: `"id":\s*3`}},
+		{name: "json path", assertion: entity.Assertion{Type: entity.AssertionJSONPath, Target: "$.status", Value: "ok"}, want: true},
+		{name: "json path index", assertion: entity.Assertion{Type: entity.AssertionJSONPath, Target: "$.data.items[1].id", Value: "2"}, want: true},
+		{name: "json path dotted index", assertion: entity.Assertion{Type: entity.AssertionJSONPath, Target: "data.items.0.id", Value: "1"}, want: true},
+		{name: "json path wrong value", assertion: entity.Assertion{Type: entity.AssertionJSONPath, Target: "$.status", Value: "fail"}},
+		{name: "json path out of range", assertion: entity.Assertion{Type: entity.AssertionJSONPath, Target: "$.data.items[2].id", Value: "1"}},
+		{name: "header present", assertion: entity.Assertion{Type: entity.AssertionHeaderPresent, Target: "content-type"}, want: true},
+		{name: "header missing", assertion: entity.Assertion{Type: entity.AssertionHeaderPresent, Target: "X-Request-Id"}},
+		{name: "header matches", assertion: entity.Assertion{Type: entity.AssertionHeaderMatches, Target: "Content-Type", Value: "^application/json"}, want: true},
+		{name: "header does not match", assertion: entity.Assertion{Type: entity.AssertionHeaderMatches, Target: "Content-Type", Value: "^text/html"}},
+		{name: "max body size", assertion: entity.Assertion{Type: entity.AssertionMaxBodySize, Value: strconv.Itoa(len(body))}, want: true},
+		{name: "max body size exceeded", assertion: entity.Assertion{Type: entity.AssertionMaxBodySize, Value: strconv.Itoa(len(body) - 1)}},
+		{name: "unknown type", assertion: entity.Assertion{Type: "unknown"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := assert([]entity.Assertion{test.assertion}, header, body, int64(len(body)))
+			if (got == "") != test.want {
+				t.Errorf("failed assertion = %q, want passed %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAssertReportsFirstFailed(t *testing.T) {
+	got := assert([]entity.Assertion{
+		{Type: entity.AssertionBodyContains, Value: "ok"},
+		{Type: entity.AssertionHeaderPresent, Target: "X-Request-Id"},
+		{Type: entity.AssertionBodyContains, Value: "missing"},
+	}, http.Header{}, []byte("ok"), 2)
+
+	if got != "header_present X-Request-Id" {
+		t.Errorf("failed assertion = %q, want header_present X-Request-Id", got)
+	}
+}
+
+func TestReadBodyMaxBodySize(t *testing.T) {
+	tests := []struct {
+		name     string
+		bodySize int
+		maxSize  int
+		want     bool
+	}{
+		{name: "below limit", bodySize: maxBodySize, maxSize: maxBodySize, want: true},
+		{name: "over limit", bodySize: maxBodySize + 1, maxSize: maxBodySize},
+		{name: "over larger limit", bodySize: maxBodySize + 2, maxSize: maxBodySize + 1},
+		{name: "below larger limit", bodySize: maxBodySize + 1, maxSize: maxBodySize + 1, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assertions := []entity.Assertion{
+				{Type: entity.AssertionBodyContains, Value: "a"},
+				{Type: entity.AssertionMaxBodySize, Value: strconv.Itoa(test.maxSize)},
+			}
+
+			body, size := readBody(bytes.NewReader(bytes.Repeat([]byte("a"), test.bodySize)), assertions)
+			if len(body) > maxBodySize {
+				t.Errorf("kept %d bytes, want at most %d", len(body), maxBodySize)
+			}
+
+			got := assert(assertions, http.Header{}, body, size)
+			if (got == "") != test.want {
+				t.Errorf("failed assertion = %q, want passed %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegexpCache(t *testing.T) {
+	cache := &regexpCache{patterns: map[string]*regexp.Regexp{}}
+
+	first, err := cache.compile(`^ok$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := cache.compile(`^ok$`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != second {
+		t.Error("pattern must be compiled once")
+	}
+
+	_, err = cache.compile(`(`)
+	if err == nil {
+		t.Error("invalid pattern must fail")
+	}
+
+	for i := 0; i < maxCachedRegexps; i++ {
+		_, _ = cache.compile(strconv.Itoa(i))
+	}
+
+	if len(cache.patterns) > maxCachedRegexps {
+		t.Errorf("cached %d patterns, want at most %d", len(cache.patterns), maxCachedRegexps)
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"estimate/internal/entity"
+	"sync"
+)
+
+// certificateRecorder проверяет цепочку сертификатов вместо стандартной проверки tls,
+// чтобы сохранить сведения о сертификате даже при ошибке проверки,
+// при редиректах сохраняется сертификат последнего запроса
+type certificateRecorder struct {
+	mu          sync.Mutex
+	certificate entity.Certificate
+}
+
+func newCertificateRecorder() *certificateRecorder {
+	return &certificateRecorder{}
+}
+
+func (recorder *certificateRecorder) TLSConfig() *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyConnection:   recorder.verifyConnection,
+	}
+}
+
+func (recorder *certificateRecorder) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	leaf := state.PeerCertificates[0]
+	expiresAt := leaf.NotAfter
+
+	intermediates := x509.NewCertPool()
+	for _, certificate := range state.PeerCertificates[1:] {
+		intermediates.AddCert(certificate)
+
+		if certificate.NotAfter.Before(expiresAt) {
+			expiresAt = certificate.NotAfter
+		}
+	}
+
+	certificate := entity.Certificate{
+		ExpiresAt: &expiresAt,
+		Issuer:    leaf.Issuer.String(),
+		SANs:      append([]string{}, leaf.DNSNames...),
+	}
+	for _, ip := range leaf.IPAddresses {
+		certificate.SANs = append(certificate.SANs, ip.String())
+	}
+
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       state.ServerName,
+		Intermediates: intermediates,
+	})
+	if err != nil {
+		certificate.Error = err.Error()
+	}
+
+	recorder.mu.Lock()
+	recorder.certificate = certificate
+	recorder.mu.Unlock()
+
+	return err
+}
+
+func (recorder *certificateRecorder) Certificate() entity.Certificate {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	certificate := recorder.certificate
+	if certificate.SANs == nil {
+		certificate.SANs = []string{}
+	}
+
+	return certificate
+}
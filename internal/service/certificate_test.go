@@ -0,0 +1,186 @@
+package service
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"estimate/internal/entity"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// newTestCertificate выпускает сертификат, подписанный parent, при parent == nil сертификат самоподписанный
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.certificate, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testCertificate{certificate: certificate, key: key}
+}
+
+func TestCertificateRecorderSelfSigned(t *testing.T) {
+	notAfter := time.Now().Add(30 * 24 * time.Hour).Truncate(time.Second).UTC()
+	certificate := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "estimate test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"example.test"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}, nil)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{
+			Certificate: [][]byte{certificate.certificate.Raw},
+			PrivateKey:  certificate.key,
+		}},
+	}
+	// сервер пишет в лог отказ клиента в рукопожатии, это ожидаемо
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.StartTLS()
+	defer server.Close()
+
+	check, err := (&websiteService{}).Check(entity.Website{
+		URL:         server.Listener.Addr().String(),
+		CheckConfig: entity.CheckConfig{Scheme: entity.SchemeHTTPS},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.Available {
+		t.Fatal("website with self-signed certificate must be unavailable")
+	}
+
+	if check.ErrorClass != entity.ErrorClassTLS {
+		t.Errorf("error class = %q, want %q", check.ErrorClass, entity.ErrorClassTLS)
+	}
+
+	if check.Certificate.ExpiresAt == nil || !check.Certificate.ExpiresAt.Equal(notAfter) {
+		t.Errorf("expires at = %v, want %v", check.Certificate.ExpiresAt, notAfter)
+	}
+
+	if check.Certificate.Issuer != "CN=estimate test" {
+		t.Errorf("issuer = %q, want %q", check.Certificate.Issuer, "CN=estimate test")
+	}
+
+	wantSANs := []string{"example.test", "127.0.0.1"}
+	if !reflect.DeepEqual(check.Certificate.SANs, wantSANs) {
+		t.Errorf("sans = %v, want %v", check.Certificate.SANs, wantSANs)
+	}
+
+	if check.Certificate.Error == "" {
+		t.Error("certificate error must be recorded")
+	}
+}
+
+func TestCertificateRecorderChainExpiry(t *testing.T) {
+	rootNotAfter := time.Now().Add(365 * 24 * time.Hour).Truncate(time.Second).UTC()
+	intermediateNotAfter := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second).UTC()
+
+	root := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "estimate root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              rootNotAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil)
+	intermediate := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "estimate intermediate"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              intermediateNotAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, &root)
+	leaf := newTestCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "example.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     rootNotAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"example.test", "www.example.test"},
+	}, &intermediate)
+
+	recorder := newCertificateRecorder()
+	err := recorder.verifyConnection(tls.ConnectionState{
+		ServerName:       "example.test",
+		PeerCertificates: []*x509.Certificate{leaf.certificate, intermediate.certificate},
+	})
+	if err == nil {
+		t.Fatal("chain with unknown root must fail verification")
+	}
+
+	certificate := recorder.Certificate()
+	if certificate.ExpiresAt == nil || !certificate.ExpiresAt.Equal(intermediateNotAfter) {
+		t.Errorf("expires at = %v, want nearest expiry in chain %v", certificate.ExpiresAt, intermediateNotAfter)
+	}
+
+	if certificate.Issuer != "CN=estimate intermediate" {
+		t.Errorf("issuer = %q, want %q", certificate.Issuer, "CN=estimate intermediate")
+	}
+
+	wantSANs := []string{"example.test", "www.example.test"}
+	if !reflect.DeepEqual(certificate.SANs, wantSANs) {
+		t.Errorf("sans = %v, want %v", certificate.SANs, wantSANs)
+	}
+
+	if certificate.Error != err.Error() {
+		t.Errorf("error = %q, want %q", certificate.Error, err.Error())
+	}
+}
+
+func TestCertificateRecorderEmpty(t *testing.T) {
+	recorder := newCertificateRecorder()
+	err := recorder.verifyConnection(tls.ConnectionState{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certificate := recorder.Certificate()
+	if certificate.ExpiresAt != nil || certificate.SANs == nil || len(certificate.SANs) != 0 {
+		t.Errorf("certificate = %+v, want empty", certificate)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"estimate/internal/entity"
+	"estimate/internal/storage"
+	"estimate/pkg/apperror"
+	"time"
+)
+
+type CheckService interface {
+	History(ctx context.Context, rawURL string, from time.Time, to time.Time) ([]entity.Check, error)
+	Stats(ctx context.Context, rawURL string, window time.Duration) (entity.Stats, error)
+	Certificate(ctx context.Context, rawURL string) (entity.Check, error)
+	Redirects(ctx context.Context, rawURL string) (entity.Check, error)
+}
+
+type checkService struct {
+	storage        storage.CheckStorage
+	websiteStorage storage.WebsiteStorage
+}
+
+func NewCheckService(storage storage.CheckStorage, websiteStorage storage.WebsiteStorage) CheckService {
+	return &checkService{
+		storage:        storage,
+		websiteStorage: websiteStorage,
+	}
+}
+
+func (service *checkService) History(ctx context.Context, rawURL string, from time.Time, to time.Time) ([]entity.Check, error) {
+	website, err := service.website(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, err := service.storage.Select(ctx, website.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return checks, nil
+}
+
+func (service *checkService) Stats(ctx context.Context, rawURL string, window time.Duration) (entity.Stats, error) {
+	website, err := service.website(ctx, rawURL)
+	if err != nil {
+		return entity.Stats{}, err
+	}
+
+	stats, err := service.storage.Stats(ctx, website.ID, time.Now().Add(-window))
+	if err != nil {
+		return entity.Stats{}, err
+	}
+
+	return stats, nil
+}
+
+// Certificate возвращает последнюю проверку сайта, в которой были получены сведения о сертификате
+func (service *checkService) Certificate(ctx context.Context, rawURL string) (entity.Check, error) {
+	website, err := service.website(ctx, rawURL)
+	if err != nil {
+		return entity.Check{}, err
+	}
+
+	check, err := service.storage.LatestWithCertificate(ctx, website.ID)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Check{}, apperr.WithMessage("certificate not found")
+		}
+
+		return entity.Check{}, err
+	}
+
+	return check, nil
+}
+
+// Redirects возвращает последнюю проверку сайта вместе с цепочкой редиректов
+func (service *checkService) Redirects(ctx context.Context, rawURL string) (entity.Check, error) {
+	website, err := service.website(ctx, rawURL)
+	if err != nil {
+		return entity.Check{}, err
+	}
+
+	check, err := service.storage.Latest(ctx, website.ID)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Check{}, apperr.WithMessage("check not found")
+		}
+
+		return entity.Check{}, err
+	}
+
+	return check, nil
+}
+
+func (service *checkService) website(ctx context.Context, rawURL string) (entity.Website, error) {
+	host, err := parseHost(rawURL)
+	if err != nil {
+		return entity.Website{}, err
+	}
+
+	website, err := service.websiteStorage.GetByURL(ctx, host)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Website{}, apperr.WithMessage("website not found")
+		}
+
+		return entity.Website{}, err
+	}
+
+	return website, nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"estimate/internal/entity"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// classifyError определяет, на каком этапе запроса произошла ошибка
+func classifyError(err error) entity.ErrorClass {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return entity.ErrorClassDNS
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) ||
+		errors.As(err, &netErr) && netErr.Timeout() {
+		return entity.ErrorClassTimeout
+	}
+
+	if isTLSError(err) {
+		return entity.ErrorClassTLS
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return entity.ErrorClassConnect
+	}
+
+	return entity.ErrorClassHTTP
+}
+
+func isTLSError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+	var recordHeaderErr tls.RecordHeaderError
+	var verificationErr *tls.CertificateVerificationError
+
+	switch {
+	case errors.As(err, &unknownAuthorityErr),
+		errors.As(err, &hostnameErr),
+		errors.As(err, &certificateInvalidErr),
+		errors.As(err, &recordHeaderErr),
+		errors.As(err, &verificationErr):
+		return true
+	}
+
+	// вместо рукопожатия TLS сервер ответил по http, net/http не сохраняет исходную ошибку
+	return strings.Contains(err.Error(), "tls: ") || strings.Contains(err.Error(), "server gave HTTP response to HTTPS client")
+}
+
+// fallbackToHTTP сообщает, что по https не удалось установить соединение: порт закрыт,
+// рукопожатие TLS или проверка сертификата не прошли, при таймаутах, ошибках DNS
+// и ответах сервера повторная проверка по http не выполняется
+func fallbackToHTTP(check entity.Check) bool {
+	switch check.ErrorClass {
+	case entity.ErrorClassTLS:
+		return true
+	case entity.ErrorClassConnect:
+		return strings.Contains(check.ErrorMessage, syscall.ECONNREFUSED.Error())
+	}
+
+	return false
+}
+
+func requestFailure(err error) entity.Failure {
+	return entity.Failure{
+		ErrorClass:   classifyError(err),
+		ErrorMessage: err.Error(),
+	}
+}
+
+func statusFailure(statusCode int) entity.Failure {
+	return entity.Failure{
+		ErrorClass:   entity.ErrorClassHTTP,
+		ErrorMessage: fmt.Sprintf("unexpected status code %d", statusCode),
+	}
+}
+
+func assertionFailure(assertion string) entity.Failure {
+	return entity.Failure{
+		ErrorClass:   entity.ErrorClassAssertion,
+		ErrorMessage: "assertion failed: " + assertion,
+	}
+}
+
+func unavailableMessage(failure entity.Failure) string {
+	if failure.ErrorClass == "" {
+		return "website is unavailable"
+	}
+
+	return fmt.Sprintf("website is unavailable: %s: %s", failure.ErrorClass, failure.ErrorMessage)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"estimate/internal/entity"
+	"estimate/internal/storage"
+	"estimate/pkg/apperror"
+	"time"
+)
+
+type IncidentService interface {
+	Select(ctx context.Context, rawURL string, from time.Time, to time.Time) ([]entity.Incident, error)
+}
+
+type incidentService struct {
+	storage        storage.IncidentStorage
+	websiteStorage storage.WebsiteStorage
+}
+
+func NewIncidentService(storage storage.IncidentStorage, websiteStorage storage.WebsiteStorage) IncidentService {
+	return &incidentService{
+		storage:        storage,
+		websiteStorage: websiteStorage,
+	}
+}
+
+// Select возвращает инциденты за период, если ссылка не указана - по всем сайтам
+func (service *incidentService) Select(ctx context.Context, rawURL string, from time.Time, to time.Time) ([]entity.Incident, error) {
+	filter := entity.IncidentFilter{
+		From: from,
+		To:   to,
+	}
+
+	if rawURL != "" {
+		host, err := parseHost(rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		website, err := service.websiteStorage.GetByURL(ctx, host)
+		if err != nil {
+			if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+				return nil, apperr.WithMessage("website not found")
+			}
+
+			return nil, err
+		}
+
+		filter.WebsiteID = website.ID
+	}
+
+	incidents, err := service.storage.Select(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return incidents, nil
+}
@@ -0,0 +1,20 @@
+package service
+
+import "context"
+
+type liveCheckLimitKey struct{}
+
+// WithLiveCheckLimit добавляет в контекст функцию, которая вызывается перед каждой проверкой
+// неизвестного сайта в момент запроса и запрещает ее, если возвращает ошибку
+func WithLiveCheckLimit(ctx context.Context, limit func() error) context.Context {
+	return context.WithValue(ctx, liveCheckLimitKey{}, limit)
+}
+
+func allowLiveCheck(ctx context.Context) error {
+	limit, ok := ctx.Value(liveCheckLimitKey{}).(func() error)
+	if !ok {
+		return nil
+	}
+
+	return limit()
+}
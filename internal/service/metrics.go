@@ -4,24 +4,38 @@ import (
 	"context"
 	"estimate/internal/entity"
 	"estimate/internal/storage"
+	"estimate/pkg/apperror"
+	"time"
 )
 
 type MetricsService interface {
-	Metrics(ctx context.Context) (entity.Metrics, error)
+	Record(ctx context.Context, request entity.Request) error
+	Metrics(ctx context.Context, filter entity.MetricsFilter) (entity.Metrics, error)
 }
 
+// metricsService retention - время хранения метрик, шаг больше него не может содержать данных
 type metricsService struct {
-	storage storage.MetricsStorage
+	storage   storage.MetricsStorage
+	retention time.Duration
 }
 
-func NewMetricsService(storage storage.MetricsStorage) MetricsService {
+func NewMetricsService(storage storage.MetricsStorage, retention time.Duration) MetricsService {
 	return &metricsService{
-		storage: storage,
+		storage:   storage,
+		retention: retention,
 	}
 }
 
-func (service *metricsService) Metrics(ctx context.Context) (entity.Metrics, error) {
-	metrics, err := service.storage.Metrics(ctx)
+func (service *metricsService) Record(ctx context.Context, request entity.Request) error {
+	return service.storage.Record(ctx, request)
+}
+
+func (service *metricsService) Metrics(ctx context.Context, filter entity.MetricsFilter) (entity.Metrics, error) {
+	if filter.Granularity > service.retention {
+		return nil, apperror.BadRequest.WithMessage("granularity must not exceed metrics retention " + service.retention.String())
+	}
+
+	metrics, err := service.storage.Metrics(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
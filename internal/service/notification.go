@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"estimate/internal/entity"
+	"estimate/internal/notifier"
+	"estimate/internal/storage"
+	"estimate/pkg/apperror"
+	"log"
+	"time"
+)
+
+type NotificationService interface {
+	Notify(ctx context.Context, event entity.Event)
+	Test(ctx context.Context, id int64) error
+	CreateChannel(ctx context.Context, channel entity.Channel) (entity.Channel, error)
+	GetChannel(ctx context.Context, id int64) (entity.Channel, error)
+	SelectChannels(ctx context.Context) ([]entity.Channel, error)
+	UpdateChannel(ctx context.Context, channel entity.Channel) (entity.Channel, error)
+	DeleteChannel(ctx context.Context, id int64) error
+}
+
+// notifyTimeout ограничивает время доставки события в один канал вместе с повторными попытками
+const notifyTimeout = 5 * time.Minute
+
+type notificationService struct {
+	storage  storage.ChannelStorage
+	notifier notifier.Notifier
+}
+
+func NewNotificationService(storage storage.ChannelStorage, notifier notifier.Notifier) NotificationService {
+	return &notificationService{
+		storage:  storage,
+		notifier: notifier,
+	}
+}
+
+// Notify рассылает событие во все включенные каналы, не дожидаясь доставки
+func (service *notificationService) Notify(ctx context.Context, event entity.Event) {
+	channels, err := service.storage.SelectEnabled(ctx)
+	if err != nil {
+		log.Printf("failed to select notification channels: %s\n", err)
+		return
+	}
+
+	for _, channel := range channels {
+		channel := channel
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+			defer cancel()
+
+			err := service.notifier.Send(ctx, channel, event)
+			if err != nil {
+				log.Printf("failed to notify: channel = %s, event = %s, url = %s: %s\n", channel.Name, event.Type, event.Website.URL, err)
+			}
+		}()
+	}
+}
+
+// Test отправляет в канал тестовое событие и возвращает ошибку доставки
+func (service *notificationService) Test(ctx context.Context, id int64) error {
+	channel, err := service.GetChannel(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	return service.notifier.Send(ctx, channel, entity.Event{
+		Type:       entity.EventTest,
+		OccurredAt: now,
+		Website: entity.Website{
+			URL:    "example.com",
+			Health: entity.Health{State: entity.StateDown, StateChangedAt: now},
+		},
+		From: entity.StateUp,
+		To:   entity.StateDown,
+		Check: entity.Check{
+			CheckedAt: now,
+			Scheme:    entity.SchemeHTTPS,
+			Failure: entity.Failure{
+				ErrorClass:   entity.ErrorClassTimeout,
+				ErrorMessage: "test notification",
+			},
+		},
+	})
+}
+
+func (service *notificationService) CreateChannel(ctx context.Context, channel entity.Channel) (entity.Channel, error) {
+	channel, err := service.storage.Create(ctx, channel)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.AlreadyExists); ok {
+			return entity.Channel{}, apperr.WithMessage("channel already exists")
+		}
+
+		return entity.Channel{}, err
+	}
+
+	return channel, nil
+}
+
+func (service *notificationService) GetChannel(ctx context.Context, id int64) (entity.Channel, error) {
+	channel, err := service.storage.GetByID(ctx, id)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Channel{}, apperr.WithMessage("channel not found")
+		}
+
+		return entity.Channel{}, err
+	}
+
+	return channel, nil
+}
+
+func (service *notificationService) SelectChannels(ctx context.Context) ([]entity.Channel, error) {
+	channels, err := service.storage.Select(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return channels, nil
+}
+
+func (service *notificationService) UpdateChannel(ctx context.Context, channel entity.Channel) (entity.Channel, error) {
+	err := service.storage.Update(ctx, channel)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Channel{}, apperr.WithMessage("channel not found")
+		}
+
+		if apperr, ok := apperror.Is(err, apperror.AlreadyExists); ok {
+			return entity.Channel{}, apperr.WithMessage("channel already exists")
+		}
+
+		return entity.Channel{}, err
+	}
+
+	return service.GetChannel(ctx, channel.ID)
+}
+
+func (service *notificationService) DeleteChannel(ctx context.Context, id int64) error {
+	err := service.storage.Delete(ctx, id)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return apperr.WithMessage("channel not found")
+		}
+
+		return err
+	}
+
+	return nil
+}
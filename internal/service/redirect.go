@@ -0,0 +1,49 @@
+package service
+
+import (
+	"estimate/internal/entity"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// redirectRecorder оборачивает транспорт и запоминает каждый запрос цепочки редиректов
+type redirectRecorder struct {
+	transport http.RoundTripper
+
+	mu   sync.Mutex
+	hops []entity.Hop
+}
+
+func newRedirectRecorder(transport http.RoundTripper) *redirectRecorder {
+	return &redirectRecorder{
+		transport: transport,
+	}
+}
+
+func (recorder *redirectRecorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+
+	response, err := recorder.transport.RoundTrip(request)
+
+	hop := entity.Hop{
+		URL:     request.URL.String(),
+		Latency: time.Since(start),
+	}
+	if err == nil {
+		hop.StatusCode = response.StatusCode
+	}
+
+	recorder.mu.Lock()
+	recorder.hops = append(recorder.hops, hop)
+	recorder.mu.Unlock()
+
+	return response, err
+}
+
+func (recorder *redirectRecorder) Hops() []entity.Hop {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	return append([]entity.Hop{}, recorder.hops...)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"estimate/internal/entity"
+	"sync"
+	"time"
+)
+
+const (
+	// resultHistorySize количество последних результатов, с которых можно продолжить поток
+	resultHistorySize = 1024
+	// subscriberBuffer количество результатов, которые подписчик может не успеть прочитать,
+	// при переполнении подписка закрывается и клиент переподключается с последнего ID
+	subscriberBuffer = 256
+)
+
+type ResultService interface {
+	Publish(website entity.Website, check entity.Check)
+	Subscribe(ctx context.Context, filter entity.ResultFilter) (<-chan entity.Result, error)
+	Close()
+}
+
+type subscriber struct {
+	filter  entity.ResultFilter
+	results chan entity.Result
+}
+
+type resultService struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []entity.Result
+	subscribers map[*subscriber]struct{}
+	closed      bool
+}
+
+func NewResultService() ResultService {
+	return &resultService{
+		// ID начинаются с текущего времени, чтобы после перезапуска не повторять уже выданные
+		lastID:      uint64(time.Now().UnixNano()),
+		history:     make([]entity.Result, 0, resultHistorySize),
+		subscribers: make(map[*subscriber]struct{}),
+	}
+}
+
+func (service *resultService) Publish(website entity.Website, check entity.Check) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	if service.closed {
+		return
+	}
+
+	service.lastID++
+	result := entity.Result{
+		ID:      service.lastID,
+		Website: website,
+		Check:   check,
+	}
+
+	if len(service.history) == resultHistorySize {
+		copy(service.history, service.history[1:])
+		service.history = service.history[:resultHistorySize-1]
+	}
+	service.history = append(service.history, result)
+
+	for subscriber := range service.subscribers {
+		if !subscriber.filter.Match(result) {
+			continue
+		}
+
+		select {
+		case subscriber.results <- result:
+		default:
+			service.unsubscribe(subscriber)
+		}
+	}
+}
+
+// Subscribe возвращает канал результатов, который закрывается при отмене ctx,
+// если задан filter.After, сначала отдаются пропущенные результаты из истории
+func (service *resultService) Subscribe(ctx context.Context, filter entity.ResultFilter) (<-chan entity.Result, error) {
+	urls := make([]string, len(filter.URLs))
+	for i, rawURL := range filter.URLs {
+		host, err := parseHost(rawURL)
+		if err != nil {
+			return nil, err
+		}
+
+		urls[i] = host
+	}
+	filter.URLs = urls
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	var missed []entity.Result
+	if filter.After != 0 {
+		for _, result := range service.history {
+			if result.ID > filter.After && filter.Match(result) {
+				missed = append(missed, result)
+			}
+		}
+	}
+
+	subscriber := &subscriber{
+		filter:  filter,
+		results: make(chan entity.Result, len(missed)+subscriberBuffer),
+	}
+	for _, result := range missed {
+		subscriber.results <- result
+	}
+
+	if service.closed {
+		close(subscriber.results)
+		return subscriber.results, nil
+	}
+
+	service.subscribers[subscriber] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		service.mu.Lock()
+		defer service.mu.Unlock()
+
+		service.unsubscribe(subscriber)
+	}()
+
+	return subscriber.results, nil
+}
+
+// Close закрывает все подписки, чтобы открытые потоки не задерживали остановку сервера
+func (service *resultService) Close() {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	service.closed = true
+	for subscriber := range service.subscribers {
+		service.unsubscribe(subscriber)
+	}
+}
+
+func (service *resultService) unsubscribe(subscriber *subscriber) {
+	if _, ok := service.subscribers[subscriber]; !ok {
+		return
+	}
+
+	delete(service.subscribers, subscriber)
+	close(subscriber.results)
+}
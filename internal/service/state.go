@@ -0,0 +1,83 @@
+package service
+
+import "estimate/internal/entity"
+
+// Thresholds количество последовательных проверок, необходимое для смены состояния,
+// чтобы одиночные сбои не приводили к переключениям
+type Thresholds struct {
+	Failure int
+	Success int
+}
+
+// nextHealth вычисляет состояние сайта после проверки:
+// при неудачной проверке сайт становится degraded, а после Failure неудач подряд - down,
+// при успешной проверке сайт становится up после Success успехов подряд,
+// до этого восстанавливающийся после down сайт считается degraded,
+// еще не проверявшийся сайт становится up после первой успешной проверки
+func nextHealth(health entity.Health, check entity.Check, thresholds Thresholds) entity.Health {
+	state := health.State
+	if state == "" {
+		state = entity.StateUnknown
+	}
+
+	if check.Available {
+		health.ConsecutiveSuccesses++
+		health.ConsecutiveFailures = 0
+		health.FailingSince = nil
+
+		switch {
+		case health.ConsecutiveSuccesses >= thresholds.Success, state == entity.StateUnknown:
+			state = entity.StateUp
+		case state == entity.StateDown:
+			state = entity.StateDegraded
+		}
+	} else {
+		health.ConsecutiveFailures++
+		health.ConsecutiveSuccesses = 0
+		if health.FailingSince == nil {
+			checkedAt := check.CheckedAt
+			health.FailingSince = &checkedAt
+		}
+
+		switch {
+		case health.ConsecutiveFailures >= thresholds.Failure:
+			state = entity.StateDown
+		case state == entity.StateUp, state == entity.StateUnknown:
+			state = entity.StateDegraded
+		}
+	}
+
+	if state != health.State {
+		health.State = state
+		health.StateChangedAt = check.CheckedAt
+	}
+
+	return health
+}
+
+func (thresholds Thresholds) normalize() Thresholds {
+	if thresholds.Failure < 1 {
+		thresholds.Failure = 1
+	}
+
+	if thresholds.Success < 1 {
+		thresholds.Success = 1
+	}
+
+	return thresholds
+}
+
+// incidentFromCheck открывает инцидент с момента первой из неудачных проверок, которые привели к down
+func incidentFromCheck(health entity.Health, check entity.Check) entity.Incident {
+	startedAt := check.CheckedAt
+	if health.FailingSince != nil {
+		startedAt = *health.FailingSince
+	}
+
+	return entity.Incident{
+		WebsiteID:    check.WebsiteID,
+		StartedAt:    startedAt,
+		CauseClass:   check.ErrorClass,
+		CauseMessage: check.ErrorMessage,
+	}
+}
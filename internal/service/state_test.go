@@ -0,0 +1,179 @@
+package service
+
+import (
+	"estimate/internal/entity"
+	"testing"
+	"time"
+)
+
+func TestNextHealth(t *testing.T) {
+	changedAt := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+	failingSince := changedAt.Add(time.Minute)
+	checkedAt := changedAt.Add(5 * time.Minute)
+	thresholds := Thresholds{Failure: 3, Success: 2}
+
+	tests := []struct {
+		name      string
+		health    entity.Health
+		available bool
+		want      entity.Health
+	}{
+		{
+			name:      "empty state is unknown",
+			health:    entity.Health{},
+			available: false,
+			want: entity.Health{
+				State:               entity.StateDegraded,
+				ConsecutiveFailures: 1,
+				StateChangedAt:      checkedAt,
+				FailingSince:        &checkedAt,
+			},
+		},
+		{
+			name:      "unknown is up after first success",
+			health:    entity.Health{State: entity.StateUnknown, StateChangedAt: changedAt},
+			available: true,
+			want: entity.Health{
+				State:                entity.StateUp,
+				ConsecutiveSuccesses: 1,
+				StateChangedAt:       checkedAt,
+			},
+		},
+		{
+			name:      "up stays up",
+			health:    entity.Health{State: entity.StateUp, ConsecutiveSuccesses: 5, StateChangedAt: changedAt},
+			available: true,
+			want: entity.Health{
+				State:                entity.StateUp,
+				ConsecutiveSuccesses: 6,
+				StateChangedAt:       changedAt,
+			},
+		},
+		{
+			name:      "up is degraded after failure",
+			health:    entity.Health{State: entity.StateUp, ConsecutiveSuccesses: 5, StateChangedAt: changedAt},
+			available: false,
+			want: entity.Health{
+				State:               entity.StateDegraded,
+				ConsecutiveFailures: 1,
+				StateChangedAt:      checkedAt,
+				FailingSince:        &checkedAt,
+			},
+		},
+		{
+			name: "degraded is down after failure threshold",
+			health: entity.Health{
+				State:               entity.StateDegraded,
+				ConsecutiveFailures: 2,
+				StateChangedAt:      changedAt,
+				FailingSince:        &failingSince,
+			},
+			available: false,
+			want: entity.Health{
+				State:               entity.StateDown,
+				ConsecutiveFailures: 3,
+				StateChangedAt:      checkedAt,
+				FailingSince:        &failingSince,
+			},
+		},
+		{
+			name: "degraded is up after success threshold",
+			health: entity.Health{
+				State:                entity.StateDegraded,
+				ConsecutiveSuccesses: 1,
+				StateChangedAt:       changedAt,
+			},
+			available: true,
+			want: entity.Health{
+				State:                entity.StateUp,
+				ConsecutiveSuccesses: 2,
+				StateChangedAt:       checkedAt,
+			},
+		},
+		{
+			name: "down is degraded before success threshold",
+			health: entity.Health{
+				State:               entity.StateDown,
+				ConsecutiveFailures: 4,
+				StateChangedAt:      changedAt,
+				FailingSince:        &failingSince,
+			},
+			available: true,
+			want: entity.Health{
+				State:                entity.StateDegraded,
+				ConsecutiveSuccesses: 1,
+				StateChangedAt:       checkedAt,
+			},
+		},
+		{
+			name: "down stays down",
+			health: entity.Health{
+				State:               entity.StateDown,
+				ConsecutiveFailures: 4,
+				StateChangedAt:      changedAt,
+				FailingSince:        &failingSince,
+			},
+			available: false,
+			want: entity.Health{
+				State:               entity.StateDown,
+				ConsecutiveFailures: 5,
+				StateChangedAt:      changedAt,
+				FailingSince:        &failingSince,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := nextHealth(test.health, entity.Check{CheckedAt: checkedAt, Available: test.available}, thresholds)
+
+			if got.State != test.want.State ||
+				got.ConsecutiveFailures != test.want.ConsecutiveFailures ||
+				got.ConsecutiveSuccesses != test.want.ConsecutiveSuccesses ||
+				!got.StateChangedAt.Equal(test.want.StateChangedAt) {
+				t.Errorf("health = %+v, want %+v", got, test.want)
+			}
+
+			if !equalTime(got.FailingSince, test.want.FailingSince) {
+				t.Errorf("failing since = %v, want %v", got.FailingSince, test.want.FailingSince)
+			}
+		})
+	}
+}
+
+func TestIncidentStartsAtFirstFailure(t *testing.T) {
+	thresholds := Thresholds{Failure: 3, Success: 1}
+	start := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	health := entity.Health{State: entity.StateUp}
+	var check entity.Check
+	for i := 0; i < thresholds.Failure; i++ {
+		check = entity.Check{
+			WebsiteID: 1,
+			CheckedAt: start.Add(time.Duration(i) * time.Minute),
+			Failure:   entity.Failure{ErrorClass: entity.ErrorClassTimeout, ErrorMessage: "timeout"},
+		}
+		health = nextHealth(health, check, thresholds)
+	}
+
+	if health.State != entity.StateDown {
+		t.Fatalf("state = %s, want down", health.State)
+	}
+
+	incident := incidentFromCheck(health, check)
+	if !incident.StartedAt.Equal(start) {
+		t.Errorf("started at = %v, want first failure %v", incident.StartedAt, start)
+	}
+
+	if incident.WebsiteID != 1 || incident.CauseClass != entity.ErrorClassTimeout {
+		t.Errorf("incident = %+v", incident)
+	}
+}
+
+func equalTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
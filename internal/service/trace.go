@@ -0,0 +1,103 @@
+package service
+
+import (
+	"crypto/tls"
+	"estimate/internal/entity"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// phaseTracer собирает время этапов запроса с помощью httptrace,
+// при редиректах время этапов суммируется по всем запросам,
+// при параллельных попытках соединения (happy eyeballs) соединение считается
+// от первой ConnectStart до первой успешной ConnectDone
+type phaseTracer struct {
+	mu sync.Mutex
+
+	dnsStart     time.Time
+	connectStart time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+	firstByte    time.Time
+
+	phases entity.Phases
+}
+
+func newPhaseTracer() *phaseTracer {
+	return &phaseTracer{}
+}
+
+func (tracer *phaseTracer) ClientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(_ httptrace.DNSStartInfo) {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			tracer.dnsStart = time.Now()
+		},
+		DNSDone: func(_ httptrace.DNSDoneInfo) {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			tracer.phases.DNS += time.Since(tracer.dnsStart)
+		},
+		ConnectStart: func(_, _ string) {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			if tracer.connectStart.IsZero() {
+				tracer.connectStart = time.Now()
+			}
+		},
+		ConnectDone: func(_, _ string, err error) {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			// неудачные и отмененные попытки и попытки, завершившиеся после успешной, не учитываются
+			if err != nil || tracer.connectStart.IsZero() {
+				return
+			}
+
+			tracer.phases.Connect += time.Since(tracer.connectStart)
+			tracer.connectStart = time.Time{}
+		},
+		TLSHandshakeStart: func() {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			tracer.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(_ tls.ConnectionState, _ error) {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			tracer.phases.TLS += time.Since(tracer.tlsStart)
+		},
+		WroteRequest: func(_ httptrace.WroteRequestInfo) {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			tracer.wroteRequest = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+
+			tracer.firstByte = time.Now()
+			tracer.phases.TTFB += tracer.firstByte.Sub(tracer.wroteRequest)
+		},
+	}
+}
+
+// Done фиксирует окончание чтения тела ответа и возвращает собранные этапы
+func (tracer *phaseTracer) Done() entity.Phases {
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+
+	if !tracer.firstByte.IsZero() {
+		tracer.phases.Transfer = time.Since(tracer.firstByte)
+	}
+
+	return tracer.phases
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"estimate/internal/entity"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPhaseTracerParallelConnect(t *testing.T) {
+	tracer := newPhaseTracer()
+	trace := tracer.ClientTrace()
+
+	// happy eyeballs: попытка по IPv6 не удалась, вторая попытка по IPv4 стартовала позже и завершилась успешно
+	start := time.Now()
+	trace.ConnectStart("tcp", "[2001:db8::1]:443")
+	time.Sleep(20 * time.Millisecond)
+	trace.ConnectStart("tcp", "192.0.2.1:443")
+	trace.ConnectDone("tcp", "[2001:db8::1]:443", errors.New("connect: network is unreachable"))
+	time.Sleep(10 * time.Millisecond)
+	trace.ConnectDone("tcp", "192.0.2.1:443", nil)
+	elapsed := time.Since(start)
+	trace.ConnectDone("tcp", "[2001:db8::2]:443", errors.New("operation was canceled"))
+
+	connect := tracer.Done().Connect
+	if connect < 30*time.Millisecond || connect > elapsed {
+		t.Errorf("connect = %s, want from first start to successful done (30ms - %s)", connect, elapsed)
+	}
+}
+
+func TestCheckAccessTimeExcludesTransfer(t *testing.T) {
+	const transfer = 100 * time.Millisecond
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		time.Sleep(transfer)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	check, err := (&websiteService{}).Check(entity.Website{
+		URL:         server.Listener.Addr().String(),
+		CheckConfig: entity.CheckConfig{Scheme: entity.SchemeHTTP},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !check.Available {
+		t.Fatalf("check = %+v, want available", check)
+	}
+
+	if check.AccessTime >= transfer {
+		t.Errorf("access time = %s, want time to headers below %s", check.AccessTime, transfer)
+	}
+
+	if check.Transfer < transfer {
+		t.Errorf("transfer = %s, want at least %s", check.Transfer, transfer)
+	}
+}
@@ -4,49 +4,83 @@ import (
 	"context"
 	"errors"
 	"estimate/internal/entity"
+	"estimate/internal/metrics"
 	"estimate/internal/storage"
 	"estimate/pkg/apperror"
 	"estimate/pkg/worker"
+	"fmt"
 	"github.com/alejandro-carstens/gocache"
 	"github.com/corpix/uarand"
 	"github.com/goware/urlx"
+	"io"
 	"log"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"time"
 )
 
 type WebsiteService interface {
 	Watch(ctx context.Context, interval time.Duration) error
-	Check(website entity.Website) (entity.Website, error)
+	Check(website entity.Website) (entity.Check, error)
 	CheckByURL(rawURL string) (entity.Website, error)
+	Create(ctx context.Context, website entity.Website) (entity.Website, error)
+	GetByID(ctx context.Context, id int64) (entity.Website, error)
 	GetByURL(ctx context.Context, rawURL string) (entity.Website, error)
+	GetByURLs(ctx context.Context, rawURLs []string) ([]entity.Estimate, error)
 	Select(ctx context.Context) ([]entity.Website, error)
+	SelectPage(ctx context.Context, filter entity.WebsiteFilter) (entity.WebsitePage, error)
+	SelectTop(ctx context.Context, filter entity.TopFilter) ([]entity.RankedWebsite, error)
 	Update(ctx context.Context, website entity.Website) error
+	Patch(ctx context.Context, website entity.Website) (entity.Website, error)
+	Delete(ctx context.Context, id int64) error
+	SelectByCertExpiresWithin(ctx context.Context, within time.Duration) ([]entity.Website, error)
 	GetByMinAccessTime(ctx context.Context) (entity.Website, error)
 	GetByMaxAccessTime(ctx context.Context) (entity.Website, error)
 }
 
+const (
+	// maxBodySize ограничивает объем тела ответа, который сохраняется для проверок содержимого
+	maxBodySize         = 10 << 20
+	defaultTimeout      = 10 * time.Second
+	defaultMaxRedirects = 10
+	// batchWorkerCount ограничивает количество одновременных проверок неизвестных сайтов в пакетном запросе
+	batchWorkerCount = 10
+)
+
 type websiteService struct {
-	storage storage.WebsiteStorage
-	client  *http.Client
-	cache   gocache.TaggedCache
+	storage             storage.WebsiteStorage
+	checkStorage        storage.CheckStorage
+	incidentStorage     storage.IncidentStorage
+	notificationService NotificationService
+	resultService       ResultService
+	cache               gocache.TaggedCache
+	thresholds          Thresholds
+	certExpiryWindow    time.Duration
+	recorder            metrics.Recorder
 }
 
 func NewWebsiteService(
 	storage storage.WebsiteStorage,
+	checkStorage storage.CheckStorage,
+	incidentStorage storage.IncidentStorage,
+	notificationService NotificationService,
+	resultService ResultService,
 	cache gocache.TaggedCache,
+	thresholds Thresholds,
+	certExpiryWindow time.Duration,
+	recorder metrics.Recorder,
 ) WebsiteService {
-	client := &http.Client{
-		CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
-			return nil
-		},
-		Timeout: time.Second * 10,
-	}
-
 	return &websiteService{
-		storage: storage,
-		client:  client,
-		cache:   cache,
+		storage:             storage,
+		checkStorage:        checkStorage,
+		incidentStorage:     incidentStorage,
+		notificationService: notificationService,
+		resultService:       resultService,
+		cache:               cache,
+		thresholds:          thresholds.normalize(),
+		certExpiryWindow:    certExpiryWindow,
+		recorder:            recorder,
 	}
 }
 
@@ -73,11 +107,18 @@ func (service *websiteService) Watch(ctx context.Context, watchPeriod time.Durat
 }
 
 func (service *websiteService) watch(ctx context.Context) error {
+	start := time.Now()
+
 	websites, err := service.Select(ctx)
 	if err != nil && !errors.Is(err, apperror.NotFound) {
 		return err
 	}
 
+	websitesByID := make(map[int64]entity.Website, len(websites))
+	for _, website := range websites {
+		websitesByID[website.ID] = website
+	}
+
 	workerCount := 20
 
 	pool := worker.NewPool(workerCount)
@@ -98,12 +139,12 @@ func (service *websiteService) watch(ctx context.Context) error {
 
 			jobs <- worker.Job{
 				Fn: func(_ context.Context) (any, error) {
-					updatedWebsite, err := service.Check(website)
+					check, err := service.Check(website)
 					if err != nil {
 						return nil, err
 					}
 
-					return updatedWebsite, nil
+					return check, nil
 				},
 			}
 		}
@@ -116,12 +157,51 @@ func (service *websiteService) watch(ctx context.Context) error {
 			return err
 		}
 
-		updatedWebsite := result.Value.(entity.Website)
+		check := result.Value.(entity.Check)
+		service.recorder.ObserveCheck(check)
+
+		err = service.checkStorage.Create(ctx, check)
+		if err != nil {
+			return err
+		}
+
+		website := websitesByID[check.WebsiteID]
+
+		updatedWebsite := applyCheck(website, check)
+		updatedWebsite.Health = nextHealth(website.Health, check, service.thresholds)
 
 		err = service.Update(ctx, updatedWebsite)
 		if err != nil {
 			return err
 		}
+		websitesByID[updatedWebsite.ID] = updatedWebsite
+		service.resultService.Publish(updatedWebsite, check)
+
+		if updatedWebsite.State != website.State {
+			err = service.transition(ctx, entity.Transition{
+				Website: updatedWebsite,
+				From:    website.State,
+				To:      updatedWebsite.State,
+				Check:   check,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if service.certificateExpiring(updatedWebsite) {
+			service.notificationService.Notify(ctx, entity.Event{
+				Type:       entity.EventCertificateExpiring,
+				OccurredAt: check.CheckedAt,
+				Website:    updatedWebsite,
+				Check:      check,
+			})
+
+			err = service.storage.SetCertNotified(ctx, updatedWebsite.ID, *updatedWebsite.CertExpiresAt)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	_, err = service.cache.Flush()
@@ -129,76 +209,408 @@ func (service *websiteService) watch(ctx context.Context) error {
 		return apperror.Internal.WithError(err)
 	}
 
+	for i, website := range websites {
+		websites[i] = websitesByID[website.ID]
+	}
+	service.recorder.SetWebsites(websites)
+	service.recorder.ObserveWatch(time.Since(start))
+
 	return nil
 }
 
-// Check проверяет сайт и возвращает его обновленное состояние
-func (service *websiteService) Check(website entity.Website) (entity.Website, error) {
+// transition открывает инцидент при падении сайта, закрывает его при восстановлении
+// и рассылает уведомления только об этих переходах, к уведомлению о восстановлении прикладывается закрытый инцидент,
+// переходы через degraded без падения и первая проверка сайта уведомлений не вызывают
+func (service *websiteService) transition(ctx context.Context, transition entity.Transition) error {
+	event := entity.Event{
+		Type:       entity.EventStateChanged,
+		OccurredAt: transition.Check.CheckedAt,
+		Website:    transition.Website,
+		From:       transition.From,
+		To:         transition.To,
+		Check:      transition.Check,
+	}
+
+	switch transition.To {
+	case entity.StateDown:
+		err := service.incidentStorage.Open(ctx, incidentFromCheck(transition.Website.Health, transition.Check))
+		if err != nil {
+			return err
+		}
+	case entity.StateUp:
+		incident, err := service.incidentStorage.Close(ctx, transition.Website.ID, transition.Check.CheckedAt)
+		if err != nil {
+			if _, ok := apperror.Is(err, apperror.NotFound); !ok {
+				return err
+			}
+		} else {
+			event.Incident = &incident
+		}
+	}
+
+	if transition.To == entity.StateDown || event.Incident != nil {
+		service.notificationService.Notify(ctx, event)
+	}
+
+	return nil
+}
+
+// certificateExpiring сообщает, что сертификат истекает в пределах certExpiryWindow
+// и уведомление о нем еще не отправлялось, после выпуска нового сертификата уведомление отправляется снова
+func (service *websiteService) certificateExpiring(website entity.Website) bool {
+	if service.certExpiryWindow <= 0 || website.CertExpiresAt == nil {
+		return false
+	}
+
+	if time.Until(*website.CertExpiresAt) > service.certExpiryWindow {
+		return false
+	}
+
+	return website.CertNotifiedExpiresAt == nil || !website.CertNotifiedExpiresAt.Equal(*website.CertExpiresAt)
+}
+
+// Check проверяет сайт и возвращает результат проверки,
+// в режиме auto, если соединение по https отклонено или не прошло TLS, сайт проверяется повторно по http,
+// если и по http сайт недоступен, возвращается ошибка https с добавленной ошибкой http
+func (service *websiteService) Check(website entity.Website) (entity.Check, error) {
+	switch website.Scheme {
+	case entity.SchemeHTTP:
+		return service.probe(website, entity.SchemeHTTP)
+	case entity.SchemeAuto:
+		check, err := service.probe(website, entity.SchemeHTTPS)
+		if err != nil || !fallbackToHTTP(check) {
+			return check, err
+		}
+
+		httpCheck, err := service.probe(website, entity.SchemeHTTP)
+		if err != nil || httpCheck.Available {
+			return httpCheck, err
+		}
+
+		check.ErrorMessage = fmt.Sprintf("%s (http: %s)", check.ErrorMessage, httpCheck.ErrorMessage)
+
+		return check, nil
+	default:
+		return service.probe(website, entity.SchemeHTTPS)
+	}
+}
+
+// probe выполняет один запрос к сайту по указанному протоколу
+func (service *websiteService) probe(website entity.Website, scheme string) (entity.Check, error) {
 	url, err := urlx.Parse(website.URL)
 	if err != nil {
-		return entity.Website{}, apperror.BadRequest.WithError(err)
+		return entity.Check{}, apperror.BadRequest.WithError(err)
+	}
+	url.Scheme = scheme
+
+	method := website.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var body io.Reader
+	if website.Body != "" {
+		body = strings.NewReader(website.Body)
 	}
-	url.Scheme = "https"
 
-	request, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	request, err := http.NewRequest(method, url.String(), body)
 	if err != nil {
-		return entity.Website{}, apperror.Internal.WithError(err)
+		return entity.Check{}, apperror.Internal.WithError(err)
 	}
 	request.Header.Set("User-Agent", uarand.GetRandom())
+	for key, value := range website.Headers {
+		request.Header.Set(key, value)
+	}
+
+	tracer := newPhaseTracer()
+	request = request.WithContext(httptrace.WithClientTrace(request.Context(), tracer.ClientTrace()))
 
-	website.LastCheckAt = time.Now()
+	check := entity.Check{
+		WebsiteID: website.ID,
+		CheckedAt: time.Now(),
+		Scheme:    scheme,
+	}
+
+	client, certificates, redirects := newClient(website.CheckConfig)
 
-	response, err := service.client.Do(request)
+	response, err := client.Do(request)
+	// время доступа - время до получения заголовков ответа, чтение тела учитывается отдельно в Transfer
+	accessTime := time.Since(check.CheckedAt)
+	check.Certificate = certificates.Certificate()
+	check.Redirects = redirects.Hops()
 	if err != nil {
-		website.StatusCode = 0
+		check.StatusCode = 0
+		check.Failure = requestFailure(err)
 	} else {
+		body, size := readBody(response.Body, website.Assertions)
 		_ = response.Body.Close()
 
-		website.StatusCode = response.StatusCode
-		if website.StatusCode == http.StatusOK {
-			website.AccessTime = time.Since(website.LastCheckAt)
+		check.StatusCode = response.StatusCode
+		check.FailedAssertion = assert(website.Assertions, response.Header, body, size)
+		switch {
+		case !accepted(website.AcceptedStatuses, check.StatusCode):
+			check.Failure = statusFailure(check.StatusCode)
+		case check.FailedAssertion != "":
+			check.Failure = assertionFailure(check.FailedAssertion)
+		default:
+			check.Available = true
+		}
+
+		if check.Available {
+			check.AccessTime = accessTime
+			check.Phases = tracer.Done()
 		}
 	}
 
-	return website, nil
+	return check, nil
+}
+
+// newClient создает клиент без переиспользования соединений,
+// чтобы каждая проверка измеряла установку соединения заново
+func newClient(config entity.CheckConfig) (*http.Client, *certificateRecorder, *redirectRecorder) {
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	maxRedirects := config.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+
+	certificates := newCertificateRecorder()
+	redirects := newRedirectRecorder(&http.Transport{
+		Proxy:             http.ProxyFromEnvironment,
+		DisableKeepAlives: true,
+		ForceAttemptHTTP2: true,
+		TLSClientConfig:   certificates.TLSConfig(),
+	})
+
+	client := &http.Client{
+		Transport: redirects,
+		CheckRedirect: func(_ *http.Request, via []*http.Request) error {
+			if !config.FollowRedirects {
+				return http.ErrUseLastResponse
+			}
+
+			if len(via) > maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+
+			return nil
+		},
+		Timeout: timeout,
+	}
+
+	return client, certificates, redirects
+}
+
+// accepted проверяет, входит ли код ответа в допустимые диапазоны,
+// если диапазоны не заданы, допустимым считается только 200
+func accepted(ranges []entity.StatusRange, statusCode int) bool {
+	if len(ranges) == 0 {
+		return statusCode == http.StatusOK
+	}
+
+	for _, r := range ranges {
+		if statusCode >= r.From && statusCode <= r.To {
+			return true
+		}
+	}
+
+	return false
 }
 
 // CheckByURL проверяет сайт по ссылке и возвращает его обновленное состояние, возвращет ошибку, если сайт недоступен
 func (service *websiteService) CheckByURL(rawURL string) (entity.Website, error) {
-	website, err := service.Check(entity.Website{URL: rawURL})
+	website := entity.Website{
+		URL: rawURL,
+		CheckConfig: entity.CheckConfig{
+			Scheme:          entity.SchemeHTTPS,
+			FollowRedirects: true,
+		},
+	}
+	if scheme, ok := ExplicitScheme(rawURL); ok {
+		website.Scheme = scheme
+	}
+
+	check, err := service.Check(website)
 	if err != nil {
 		return entity.Website{}, err
 	}
 
+	return applyCheck(website, check), nil
+}
+
+func (service *websiteService) Create(ctx context.Context, website entity.Website) (entity.Website, error) {
+	host, err := parseHost(website.URL)
+	if err != nil {
+		return entity.Website{}, err
+	}
+	website.URL = host
+
+	website, err = service.storage.Create(ctx, website)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.AlreadyExists); ok {
+			return entity.Website{}, apperr.WithMessage("website already exists")
+		}
+
+		return entity.Website{}, err
+	}
+
+	return website, nil
+}
+
+func (service *websiteService) GetByID(ctx context.Context, id int64) (entity.Website, error) {
+	website, err := service.storage.GetByID(ctx, id)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Website{}, apperr.WithMessage("website not found")
+		}
+
+		return entity.Website{}, err
+	}
+
 	return website, nil
 }
 
 func (service *websiteService) GetByURL(ctx context.Context, rawURL string) (entity.Website, error) {
-	url, err := urlx.Parse(rawURL)
+	host, err := parseHost(rawURL)
 	if err != nil {
-		return entity.Website{}, apperror.BadRequest.WithError(err)
+		return entity.Website{}, err
 	}
 
 	var website entity.Website
-	website, err = service.storage.GetByURL(ctx, url.Host)
+	website, err = service.storage.GetByURL(ctx, host)
 	if err != nil {
 		if !errors.Is(err, apperror.NotFound) {
 			return entity.Website{}, err
 		}
 
+		err = allowLiveCheck(ctx)
+		if err != nil {
+			return entity.Website{}, err
+		}
+
 		website, err = service.CheckByURL(rawURL)
 		if err != nil {
 			return entity.Website{}, err
 		}
 	}
 
-	if website.StatusCode != http.StatusOK {
-		return entity.Website{}, apperror.Unavailable.WithMessage("website is unavailable")
+	if !website.Available {
+		return entity.Website{}, apperror.Unavailable.WithMessage(unavailableMessage(website.Failure))
 	}
 
 	return website, nil
 }
 
+// GetByURLs возвращает результаты для каждого адреса в порядке запроса, известные сайты загружаются одним запросом,
+// неизвестные проверяются параллельно, каждая такая проверка учитывается в лимите проверок в момент запроса
+func (service *websiteService) GetByURLs(ctx context.Context, rawURLs []string) ([]entity.Estimate, error) {
+	estimates := make([]entity.Estimate, len(rawURLs))
+	hosts := make([]string, len(rawURLs))
+	for i, rawURL := range rawURLs {
+		estimates[i].URL = rawURL
+
+		hosts[i], estimates[i].Err = parseHost(rawURL)
+	}
+
+	websites, err := service.storage.SelectByURLs(ctx, hosts)
+	if err != nil {
+		return nil, err
+	}
+
+	websitesByHost := make(map[string]entity.Website, len(websites))
+	for _, website := range websites {
+		websitesByHost[website.URL] = website
+	}
+
+	// одинаковые неизвестные адреса проверяются один раз
+	unknown := make(map[string]string)
+	for i, rawURL := range rawURLs {
+		if estimates[i].Err != nil {
+			continue
+		}
+
+		if _, ok := websitesByHost[hosts[i]]; !ok {
+			if _, ok = unknown[hosts[i]]; !ok {
+				unknown[hosts[i]] = rawURL
+			}
+		}
+	}
+
+	checked := service.checkUnknown(ctx, unknown)
+
+	for i := range estimates {
+		if estimates[i].Err != nil {
+			continue
+		}
+
+		website, ok := websitesByHost[hosts[i]]
+		if !ok {
+			result := checked[hosts[i]]
+			if result.Err != nil {
+				estimates[i].Err = result.Err
+				continue
+			}
+
+			website = result.Website
+		}
+
+		if !website.Available {
+			estimates[i].Err = apperror.Unavailable.WithMessage(unavailableMessage(website.Failure))
+			continue
+		}
+
+		estimates[i].Website = website
+	}
+
+	return estimates, nil
+}
+
+// checkUnknown проверяет сайты не больше чем batchWorkerCount одновременно, urls - ссылки по хостам,
+// лимит проверок вызывается последовательно перед запуском каждой проверки
+func (service *websiteService) checkUnknown(ctx context.Context, urls map[string]string) map[string]entity.Estimate {
+	checked := make(map[string]entity.Estimate, len(urls))
+	if len(urls) == 0 {
+		return checked
+	}
+
+	pool := worker.NewPool(batchWorkerCount)
+
+	jobs := make(chan worker.Job, batchWorkerCount)
+	pool.AddJobs(jobs)
+
+	go func() {
+		defer close(jobs)
+
+		for host, rawURL := range urls {
+			host, rawURL := host, rawURL
+
+			err := allowLiveCheck(ctx)
+
+			jobs <- worker.Job{
+				Fn: func(_ context.Context) (any, error) {
+					estimate := entity.Estimate{URL: host, Err: err}
+					if err == nil {
+						estimate.Website, estimate.Err = service.CheckByURL(rawURL)
+					}
+
+					return estimate, nil
+				},
+			}
+		}
+	}()
+
+	for result := range pool.Run(ctx) {
+		estimate := result.Value.(entity.Estimate)
+		checked[estimate.URL] = estimate
+	}
+
+	return checked
+}
+
 func (service *websiteService) GetByMinAccessTime(ctx context.Context) (entity.Website, error) {
 	website, err := service.storage.GetByMinAccessTime(ctx)
 	if err != nil {
@@ -238,6 +650,37 @@ func (service *websiteService) Select(ctx context.Context) ([]entity.Website, er
 	return websites, nil
 }
 
+func (service *websiteService) SelectTop(ctx context.Context, filter entity.TopFilter) ([]entity.RankedWebsite, error) {
+	websites, err := service.storage.SelectTop(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return websites, nil
+}
+
+// SelectPage возвращает страницу сайтов и курсор следующей страницы, если она есть
+func (service *websiteService) SelectPage(ctx context.Context, filter entity.WebsiteFilter) (entity.WebsitePage, error) {
+	limit := filter.Limit
+	// лишний сайт показывает, что следующая страница не пуста
+	filter.Limit++
+
+	websites, err := service.storage.SelectPage(ctx, filter)
+	if err != nil {
+		return entity.WebsitePage{}, err
+	}
+
+	page := entity.WebsitePage{Websites: websites}
+	if len(websites) > limit {
+		page.Websites = websites[:limit]
+
+		next := entity.NewWebsiteCursor(page.Websites[limit-1])
+		page.Next = &next
+	}
+
+	return page, nil
+}
+
 func (service *websiteService) Update(ctx context.Context, website entity.Website) error {
 	err := service.storage.Update(ctx, website)
 	if err != nil {
@@ -246,3 +689,114 @@ func (service *websiteService) Update(ctx context.Context, website entity.Websit
 
 	return nil
 }
+
+// Patch изменяет настройки сайта, результаты проверок при этом не затрагиваются
+func (service *websiteService) Patch(ctx context.Context, website entity.Website) (entity.Website, error) {
+	host, err := parseHost(website.URL)
+	if err != nil {
+		return entity.Website{}, err
+	}
+	website.URL = host
+
+	err = service.storage.Patch(ctx, website)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return entity.Website{}, apperr.WithMessage("website not found")
+		}
+
+		if apperr, ok := apperror.Is(err, apperror.AlreadyExists); ok {
+			return entity.Website{}, apperr.WithMessage("website already exists")
+		}
+
+		return entity.Website{}, err
+	}
+
+	_, err = service.cache.Flush()
+	if err != nil {
+		return entity.Website{}, apperror.Internal.WithError(err)
+	}
+
+	return service.GetByID(ctx, website.ID)
+}
+
+func (service *websiteService) Delete(ctx context.Context, id int64) error {
+	err := service.storage.Delete(ctx, id)
+	if err != nil {
+		if apperr, ok := apperror.Is(err, apperror.NotFound); ok {
+			return apperr.WithMessage("website not found")
+		}
+
+		return err
+	}
+
+	_, err = service.cache.Flush()
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
+
+func (service *websiteService) SelectByCertExpiresWithin(ctx context.Context, within time.Duration) ([]entity.Website, error) {
+	websites, err := service.storage.SelectByCertExpiresBefore(ctx, time.Now().Add(within))
+	if err != nil {
+		return nil, err
+	}
+
+	return websites, nil
+}
+
+// applyCheck переносит результат проверки в последнее состояние сайта,
+// время доступа обновляется только для успешных проверок
+func applyCheck(website entity.Website, check entity.Check) entity.Website {
+	website.LastCheckAt = check.CheckedAt
+	website.StatusCode = check.StatusCode
+	website.Available = check.Available
+	website.CheckedScheme = check.Scheme
+	website.Failure = check.Failure
+	if check.ExpiresAt != nil {
+		website.CertExpiresAt = check.ExpiresAt
+	}
+	if check.Available {
+		website.AccessTime = check.AccessTime
+		website.Phases = check.Phases
+	}
+
+	return website
+}
+
+// parseHost приводит ссылку к виду, в котором она хранится в базе данных: хост и порт без протокола,
+// порт по умолчанию для явно указанного протокола отбрасывается
+func parseHost(rawURL string) (string, error) {
+	url, err := urlx.Parse(rawURL)
+	if err != nil {
+		return "", apperror.BadRequest.WithError(err).WithMessage("invalid url")
+	}
+
+	if url.Host == "" {
+		return "", apperror.BadRequest.WithMessage("invalid url")
+	}
+
+	if scheme, ok := ExplicitScheme(rawURL); ok {
+		if scheme == entity.SchemeHTTPS && url.Port() == "443" || scheme == entity.SchemeHTTP && url.Port() == "80" {
+			return url.Hostname(), nil
+		}
+	}
+
+	return url.Host, nil
+}
+
+// ExplicitScheme возвращает протокол, если он явно указан в ссылке
+func ExplicitScheme(rawURL string) (string, bool) {
+	scheme, _, found := strings.Cut(rawURL, "://")
+	if !found {
+		return "", false
+	}
+
+	scheme = strings.ToLower(scheme)
+	if scheme != entity.SchemeHTTPS && scheme != entity.SchemeHTTP {
+		return "", false
+	}
+
+	return scheme, true
+}
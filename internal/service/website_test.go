@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// memoryIncidentStorage хранит инциденты в памяти, как incident в Postgres допускает один открытый инцидент на сайт
+type memoryIncidentStorage struct {
+	incidents []entity.Incident
+}
+
+func (storage *memoryIncidentStorage) Open(_ context.Context, incident entity.Incident) error {
+	for _, stored := range storage.incidents {
+		if stored.WebsiteID == incident.WebsiteID && stored.EndedAt == nil {
+			return nil
+		}
+	}
+
+	incident.ID = int64(len(storage.incidents) + 1)
+	storage.incidents = append(storage.incidents, incident)
+
+	return nil
+}
+
+func (storage *memoryIncidentStorage) Close(_ context.Context, websiteID int64, endedAt time.Time) (entity.Incident, error) {
+	for i, stored := range storage.incidents {
+		if stored.WebsiteID == websiteID && stored.EndedAt == nil {
+			storage.incidents[i].EndedAt = &endedAt
+
+			return storage.incidents[i], nil
+		}
+	}
+
+	return entity.Incident{}, apperror.NotFound
+}
+
+func (storage *memoryIncidentStorage) Select(_ context.Context, _ entity.IncidentFilter) ([]entity.Incident, error) {
+	return storage.incidents, nil
+}
+
+// recordingNotificationService запоминает события вместо рассылки
+type recordingNotificationService struct {
+	NotificationService
+	events []entity.Event
+}
+
+func (service *recordingNotificationService) Notify(_ context.Context, event entity.Event) {
+	service.events = append(service.events, event)
+}
+
+func TestTransitionRecoveryAfterDown(t *testing.T) {
+	notifications := &recordingNotificationService{}
+	service := &websiteService{
+		incidentStorage:     &memoryIncidentStorage{},
+		notificationService: notifications,
+	}
+	thresholds := Thresholds{Failure: 2, Success: 2}
+	start := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	// down -> degraded -> up при Success > 1
+	website := entity.Website{ID: 1, URL: "example.com", Health: entity.Health{State: entity.StateUp}}
+	for i, available := range []bool{false, false, true, true} {
+		check := entity.Check{WebsiteID: website.ID, CheckedAt: start.Add(time.Duration(i) * time.Minute), Available: available}
+
+		updated := website
+		updated.Health = nextHealth(website.Health, check, thresholds)
+		if updated.State != website.State {
+			err := service.transition(context.Background(), entity.Transition{
+				Website: updated,
+				From:    website.State,
+				To:      updated.State,
+				Check:   check,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		website = updated
+	}
+
+	if website.State != entity.StateUp {
+		t.Fatalf("state = %s, want up", website.State)
+	}
+
+	if len(notifications.events) != 2 {
+		t.Fatalf("events = %d, want down and recovery only", len(notifications.events))
+	}
+
+	if down := notifications.events[0]; down.To != entity.StateDown || down.Incident != nil {
+		t.Errorf("first event %s -> %s, want down", down.From, down.To)
+	}
+
+	last := notifications.events[1]
+	if last.From != entity.StateDegraded || last.To != entity.StateUp {
+		t.Fatalf("last event %s -> %s, want degraded -> up", last.From, last.To)
+	}
+
+	if last.Incident == nil || last.Incident.EndedAt == nil {
+		t.Fatal("recovery event must carry the closed incident")
+	}
+
+	if !last.Incident.StartedAt.Equal(start) || !last.Incident.EndedAt.Equal(start.Add(3*time.Minute)) {
+		t.Errorf("incident = %v - %v, want %v - %v", last.Incident.StartedAt, *last.Incident.EndedAt, start, start.Add(3*time.Minute))
+	}
+}
+
+func TestTransitionWithoutDownNotNotified(t *testing.T) {
+	tests := []struct {
+		name string
+		from entity.State
+		to   entity.State
+	}{
+		{name: "up to degraded", from: entity.StateUp, to: entity.StateDegraded},
+		{name: "degraded to up", from: entity.StateDegraded, to: entity.StateUp},
+		{name: "first check", from: entity.StateUnknown, to: entity.StateUp},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			notifications := &recordingNotificationService{}
+			service := &websiteService{
+				incidentStorage:     &memoryIncidentStorage{},
+				notificationService: notifications,
+			}
+
+			err := service.transition(context.Background(), entity.Transition{
+				Website: entity.Website{ID: 1, Health: entity.Health{State: test.to}},
+				From:    test.from,
+				To:      test.to,
+				Check:   entity.Check{WebsiteID: 1, CheckedAt: time.Now()},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(notifications.events) != 0 {
+				t.Errorf("events = %+v, want none", notifications.events)
+			}
+		})
+	}
+}
+
+func TestCheckAutoScheme(t *testing.T) {
+	plain := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer plain.Close()
+
+	secure := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	// сервер пишет в лог отказ клиента в рукопожатии, это ожидаемо
+	secure.Config.ErrorLog = log.New(io.Discard, "", 0)
+	secure.StartTLS()
+	defer secure.Close()
+
+	// silent принимает соединения и ничего не отвечает
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	go func() {
+		for {
+			conn, err := silent.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	// closed адрес, на котором соединение отклоняется
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = closed.Close()
+
+	tests := []struct {
+		name       string
+		addr       string
+		available  bool
+		scheme     string
+		errorClass entity.ErrorClass
+		message    string
+	}{
+		{name: "tls handshake fails", addr: plain.Listener.Addr().String(), available: true, scheme: entity.SchemeHTTP},
+		{
+			name:       "connection refused",
+			addr:       closed.Addr().String(),
+			scheme:     entity.SchemeHTTPS,
+			errorClass: entity.ErrorClassConnect,
+			message:    "connection refused)",
+		},
+		{name: "timeout", addr: silent.Addr().String(), scheme: entity.SchemeHTTPS, errorClass: entity.ErrorClassTimeout},
+		{
+			name:       "http fails too",
+			addr:       secure.Listener.Addr().String(),
+			scheme:     entity.SchemeHTTPS,
+			errorClass: entity.ErrorClassTLS,
+			message:    "(http: unexpected status code 400)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check, err := (&websiteService{}).Check(entity.Website{
+				URL:         test.addr,
+				CheckConfig: entity.CheckConfig{Scheme: entity.SchemeAuto, Timeout: 200 * time.Millisecond},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if check.Available != test.available || check.Scheme != test.scheme || check.ErrorClass != test.errorClass {
+				t.Errorf("check = available %t, scheme %s, error class %q, want %t, %s, %q",
+					check.Available, check.Scheme, check.ErrorClass, test.available, test.scheme, test.errorClass)
+			}
+
+			if !strings.HasSuffix(check.ErrorMessage, test.message) {
+				t.Errorf("error message = %q, want suffix %q", check.ErrorMessage, test.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"estimate/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type APIKeyStorage interface {
+	Create(ctx context.Context, key entity.APIKey) (entity.APIKey, error)
+	GetByHash(ctx context.Context, hash string) (entity.APIKey, error)
+	Select(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, id int64, revokedAt time.Time) error
+	Touch(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+const apiKeyColumns = `id,
+       name,
+       prefix,
+       hash,
+       scopes,
+       created_at,
+       last_used_at,
+       revoked_at`
+
+type apiKeyStorage struct {
+	client postgres.Client
+}
+
+func NewAPIKeyStorage(client postgres.Client) APIKeyStorage {
+	return &apiKeyStorage{client: client}
+}
+
+func (storage *apiKeyStorage) Create(ctx context.Context, key entity.APIKey) (entity.APIKey, error) {
+	q := `
+INSERT INTO api_key (name, prefix, hash, scopes)
+VALUES ($1, $2, $3, $4)
+RETURNING ` + apiKeyColumns
+
+	err := storage.client.Get(ctx, &key, q, key.Name, key.Prefix, key.Hash, key.Scopes)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return entity.APIKey{}, apperror.AlreadyExists.WithError(err)
+		}
+
+		return entity.APIKey{}, apperror.Internal.WithError(err)
+	}
+
+	return key, nil
+}
+
+// GetByHash возвращает действующий ключ по хешу, отозванные ключи не возвращаются
+func (storage *apiKeyStorage) GetByHash(ctx context.Context, hash string) (entity.APIKey, error) {
+	q := `
+SELECT ` + apiKeyColumns + `
+FROM api_key
+WHERE hash = $1
+  AND revoked_at IS NULL
+`
+
+	var key entity.APIKey
+	err := storage.client.Get(ctx, &key, q, hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.APIKey{}, apperror.NotFound.WithError(err)
+		}
+
+		return entity.APIKey{}, apperror.Internal.WithError(err)
+	}
+
+	return key, nil
+}
+
+func (storage *apiKeyStorage) Select(ctx context.Context) ([]entity.APIKey, error) {
+	q := `
+SELECT ` + apiKeyColumns + `
+FROM api_key
+ORDER BY id
+`
+
+	var keys []entity.APIKey
+	err := storage.client.Select(ctx, &keys, q)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return keys, nil
+}
+
+func (storage *apiKeyStorage) Revoke(ctx context.Context, id int64, revokedAt time.Time) error {
+	q := `
+UPDATE api_key
+SET revoked_at = $1
+WHERE id = $2
+  AND revoked_at IS NULL
+`
+
+	tag, err := storage.client.Exec(ctx, q, revokedAt, id)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound
+	}
+
+	return nil
+}
+
+// Touch обновляет время последнего использования не чаще раза в минуту, чтобы не писать в базу на каждый запрос
+func (storage *apiKeyStorage) Touch(ctx context.Context, id int64, usedAt time.Time) error {
+	q := `
+UPDATE api_key
+SET last_used_at = $1
+WHERE id = $2
+  AND (last_used_at IS NULL OR last_used_at < $1 - INTERVAL '1 minute')
+`
+
+	_, err := storage.client.Exec(ctx, q, usedAt, id)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"estimate/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+type ChannelStorage interface {
+	Create(ctx context.Context, channel entity.Channel) (entity.Channel, error)
+	GetByID(ctx context.Context, id int64) (entity.Channel, error)
+	Select(ctx context.Context) ([]entity.Channel, error)
+	SelectEnabled(ctx context.Context) ([]entity.Channel, error)
+	Update(ctx context.Context, channel entity.Channel) error
+	Delete(ctx context.Context, id int64) error
+}
+
+const channelColumns = `id,
+       name,
+       type,
+       config,
+       enabled,
+       created_at`
+
+type channelStorage struct {
+	client postgres.Client
+}
+
+func NewChannelStorage(client postgres.Client) ChannelStorage {
+	return &channelStorage{client: client}
+}
+
+func (storage *channelStorage) Create(ctx context.Context, channel entity.Channel) (entity.Channel, error) {
+	q := `
+INSERT INTO notification_channel (name, type, config, enabled)
+VALUES ($1, $2, $3, $4)
+RETURNING ` + channelColumns
+
+	err := storage.client.Get(ctx, &channel, q, channel.Name, channel.Type, channel.Config, channel.Enabled)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return entity.Channel{}, apperror.AlreadyExists.WithError(err)
+		}
+
+		return entity.Channel{}, apperror.Internal.WithError(err)
+	}
+
+	return channel, nil
+}
+
+func (storage *channelStorage) GetByID(ctx context.Context, id int64) (entity.Channel, error) {
+	q := `
+SELECT ` + channelColumns + `
+FROM notification_channel
+WHERE id = $1
+`
+
+	var channel entity.Channel
+	err := storage.client.Get(ctx, &channel, q, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Channel{}, apperror.NotFound.WithError(err)
+		}
+
+		return entity.Channel{}, apperror.Internal.WithError(err)
+	}
+
+	return channel, nil
+}
+
+func (storage *channelStorage) Select(ctx context.Context) ([]entity.Channel, error) {
+	q := `
+SELECT ` + channelColumns + `
+FROM notification_channel
+ORDER BY id
+`
+
+	var channels []entity.Channel
+	err := storage.client.Select(ctx, &channels, q)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return channels, nil
+}
+
+func (storage *channelStorage) SelectEnabled(ctx context.Context) ([]entity.Channel, error) {
+	q := `
+SELECT ` + channelColumns + `
+FROM notification_channel
+WHERE enabled
+ORDER BY id
+`
+
+	var channels []entity.Channel
+	err := storage.client.Select(ctx, &channels, q)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return channels, nil
+}
+
+func (storage *channelStorage) Update(ctx context.Context, channel entity.Channel) error {
+	q := `
+UPDATE notification_channel
+SET name = $1,
+    config = $2,
+    enabled = $3
+WHERE id = $4
+`
+
+	tag, err := storage.client.Exec(ctx, q, channel.Name, channel.Config, channel.Enabled, channel.ID)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return apperror.AlreadyExists.WithError(err)
+		}
+
+		return apperror.Internal.WithError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound
+	}
+
+	return nil
+}
+
+func (storage *channelStorage) Delete(ctx context.Context, id int64) error {
+	q := `
+DELETE
+FROM notification_channel
+WHERE id = $1
+`
+
+	tag, err := storage.client.Exec(ctx, q, id)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"estimate/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type CheckStorage interface {
+	Create(ctx context.Context, check entity.Check) error
+	Select(ctx context.Context, websiteID int64, from time.Time, to time.Time) ([]entity.Check, error)
+	Stats(ctx context.Context, websiteID int64, from time.Time) (entity.Stats, error)
+	Latest(ctx context.Context, websiteID int64) (entity.Check, error)
+	LatestWithCertificate(ctx context.Context, websiteID int64) (entity.Check, error)
+}
+
+type checkStorage struct {
+	client postgres.Client
+}
+
+func NewCheckStorage(client postgres.Client) CheckStorage {
+	return &checkStorage{client: client}
+}
+
+func (storage *checkStorage) Create(ctx context.Context, check entity.Check) error {
+	q := `
+INSERT INTO website_check (website_id, checked_at, access_time, status_code, scheme, available, failed_assertion,
+                           error_class, error_message, redirects, dns_time, connect_time, tls_time, ttfb_time,
+                           transfer_time, cert_expires_at, cert_issuer, cert_sans, cert_error)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
+`
+
+	_, err := storage.client.Exec(ctx, q,
+		check.WebsiteID,
+		check.CheckedAt,
+		check.AccessTime,
+		check.StatusCode,
+		check.Scheme,
+		check.Available,
+		check.FailedAssertion,
+		check.ErrorClass,
+		check.ErrorMessage,
+		check.Redirects,
+		check.DNS,
+		check.Connect,
+		check.TLS,
+		check.TTFB,
+		check.Transfer,
+		check.ExpiresAt,
+		check.Issuer,
+		check.SANs,
+		check.Error,
+	)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
+
+func (storage *checkStorage) Select(ctx context.Context, websiteID int64, from time.Time, to time.Time) ([]entity.Check, error) {
+	q := `
+SELECT id,
+       website_id,
+       checked_at,
+       access_time,
+       status_code,
+       scheme,
+       available,
+       failed_assertion,
+       error_class,
+       error_message,
+       dns_time,
+       connect_time,
+       tls_time,
+       ttfb_time,
+       transfer_time
+FROM website_check
+WHERE website_id = $1
+  AND checked_at BETWEEN $2 AND $3
+ORDER BY checked_at
+`
+
+	var checks []entity.Check
+	err := storage.client.Select(ctx, &checks, q, websiteID, from, to)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return checks, nil
+}
+
+func (storage *checkStorage) Stats(ctx context.Context, websiteID int64, from time.Time) (entity.Stats, error) {
+	q := `
+SELECT count(*) AS count,
+       count(*) FILTER (WHERE available) AS success_count,
+       COALESCE(min(access_time) FILTER (WHERE available), '0') AS min,
+       COALESCE(max(access_time) FILTER (WHERE available), '0') AS max,
+       COALESCE(avg(access_time) FILTER (WHERE available), '0') AS mean,
+       COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY access_time) FILTER (WHERE available), '0') AS p50,
+       COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY access_time) FILTER (WHERE available), '0') AS p90,
+       COALESCE(percentile_cont(0.99) WITHIN GROUP (ORDER BY access_time) FILTER (WHERE available), '0') AS p99
+FROM website_check
+WHERE website_id = $1
+  AND checked_at >= $2
+`
+
+	var stats entity.Stats
+	err := storage.client.Get(ctx, &stats, q, websiteID, from)
+	if err != nil {
+		return entity.Stats{}, apperror.Internal.WithError(err)
+	}
+
+	return stats, nil
+}
+
+func (storage *checkStorage) Latest(ctx context.Context, websiteID int64) (entity.Check, error) {
+	q := `
+SELECT id,
+       website_id,
+       checked_at,
+       access_time,
+       status_code,
+       scheme,
+       available,
+       failed_assertion,
+       redirects
+FROM website_check
+WHERE website_id = $1
+ORDER BY checked_at DESC
+LIMIT 1
+`
+
+	var check entity.Check
+	err := storage.client.Get(ctx, &check, q, websiteID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Check{}, apperror.NotFound.WithError(err)
+		}
+
+		return entity.Check{}, apperror.Internal.WithError(err)
+	}
+
+	return check, nil
+}
+
+func (storage *checkStorage) LatestWithCertificate(ctx context.Context, websiteID int64) (entity.Check, error) {
+	q := `
+SELECT id,
+       website_id,
+       checked_at,
+       access_time,
+       status_code,
+       scheme,
+       available,
+       failed_assertion,
+       cert_expires_at,
+       cert_issuer,
+       cert_sans,
+       cert_error
+FROM website_check
+WHERE website_id = $1
+  AND (cert_expires_at IS NOT NULL OR cert_error <> '')
+ORDER BY checked_at DESC
+LIMIT 1
+`
+
+	var check entity.Check
+	err := storage.client.Get(ctx, &check, q, websiteID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Check{}, apperror.NotFound.WithError(err)
+		}
+
+		return entity.Check{}, apperror.Internal.WithError(err)
+	}
+
+	return check, nil
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"estimate/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type IncidentStorage interface {
+	Open(ctx context.Context, incident entity.Incident) error
+	Close(ctx context.Context, websiteID int64, endedAt time.Time) (entity.Incident, error)
+	Select(ctx context.Context, filter entity.IncidentFilter) ([]entity.Incident, error)
+}
+
+type incidentStorage struct {
+	client postgres.Client
+}
+
+func NewIncidentStorage(client postgres.Client) IncidentStorage {
+	return &incidentStorage{client: client}
+}
+
+func (storage *incidentStorage) Open(ctx context.Context, incident entity.Incident) error {
+	q := `
+INSERT INTO incident (website_id, started_at, cause_class, cause_message)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (website_id) WHERE ended_at IS NULL DO NOTHING
+`
+
+	_, err := storage.client.Exec(ctx, q, incident.WebsiteID, incident.StartedAt, incident.CauseClass, incident.CauseMessage)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
+
+// Close закрывает открытый инцидент сайта и возвращает его, если открытого инцидента нет - apperror.NotFound
+func (storage *incidentStorage) Close(ctx context.Context, websiteID int64, endedAt time.Time) (entity.Incident, error) {
+	q := `
+UPDATE incident
+SET ended_at = $1
+WHERE website_id = $2
+  AND ended_at IS NULL
+RETURNING id, website_id, started_at, ended_at, cause_class, cause_message
+`
+
+	var incident entity.Incident
+	err := storage.client.Get(ctx, &incident, q, endedAt, websiteID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Incident{}, apperror.NotFound.WithError(err)
+		}
+
+		return entity.Incident{}, apperror.Internal.WithError(err)
+	}
+
+	return incident, nil
+}
+
+func (storage *incidentStorage) Select(ctx context.Context, filter entity.IncidentFilter) ([]entity.Incident, error) {
+	q := `
+SELECT i.id,
+       i.website_id,
+       w.url,
+       i.started_at,
+       i.ended_at,
+       i.cause_class,
+       i.cause_message
+FROM incident i
+         JOIN website w ON w.id = i.website_id
+WHERE ($1::BIGINT = 0 OR i.website_id = $1)
+  AND i.started_at <= $3
+  AND (i.ended_at IS NULL OR i.ended_at >= $2)
+ORDER BY i.started_at DESC
+`
+
+	var incidents []entity.Incident
+	err := storage.client.Select(ctx, &incidents, q, filter.WebsiteID, filter.From, filter.To)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return incidents, nil
+}
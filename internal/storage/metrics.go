@@ -3,41 +3,205 @@ package storage
 import (
 	"context"
 	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"fmt"
 	"github.com/redis/go-redis/v9"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type MetricsStorage interface {
-	Metrics(ctx context.Context) (entity.Metrics, error)
+	Record(ctx context.Context, request entity.Request) error
+	Metrics(ctx context.Context, filter entity.MetricsFilter) (entity.Metrics, error)
 }
 
+const (
+	countField      = "count"
+	latencySumField = "latency_sum"
+	// infBucket интервал для запросов дольше последней границы entity.LatencyBuckets
+	infBucket = "+Inf"
+)
+
+// metricsStorage хранит метрики в hash на каждую минуту с ключом metrics:minute:<unix>,
+// поля имеют вид <field>|<method>|<class>|<route>, ключ удаляется по истечении retention
 type metricsStorage struct {
-	client *redis.Client
-	prefix string
+	client    *redis.Client
+	prefix    string
+	retention time.Duration
 }
 
-func NewMetricsStorage(client *redis.Client) MetricsStorage {
+func NewMetricsStorage(client *redis.Client, retention time.Duration) MetricsStorage {
 	return &metricsStorage{
-		client: client,
-		prefix: "metrics:",
+		client:    client,
+		prefix:    "metrics:minute:",
+		retention: retention,
 	}
 }
 
-func (storage *metricsStorage) Metrics(ctx context.Context) (entity.Metrics, error) {
-	keys := storage.client.Keys(ctx, storage.prefix+"*").Val()
+func (storage *metricsStorage) Record(ctx context.Context, request entity.Request) error {
+	key := storage.key(time.Now())
+	series := request.Method + "|" + statusClass(request.Status) + "|" + request.Route
 
-	metrics := make(entity.Metrics, len(keys))
-	for i, key := range keys {
-		count, err := storage.client.Get(ctx, key).Int()
-		if err != nil {
-			return nil, err
-		}
+	pipe := storage.client.Pipeline()
+	pipe.HIncrBy(ctx, key, countField+"|"+series, 1)
+	pipe.HIncrBy(ctx, key, latencySumField+"|"+series, request.Duration.Microseconds())
+	pipe.HIncrBy(ctx, key, bucket(request.Duration)+"|"+series, 1)
+	pipe.Expire(ctx, key, storage.retention)
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
+
+// Metrics собирает метрики из минутных hash за период и группирует их в точки по filter.Granularity
+func (storage *metricsStorage) Metrics(ctx context.Context, filter entity.MetricsFilter) (entity.Metrics, error) {
+	start := filter.From.Truncate(filter.Granularity)
+	pointCount := int(filter.To.Sub(start)/filter.Granularity) + 1
+
+	// данные старше retention уже удалены, поэтому минуты за пределами хранения не запрашиваются
+	from := filter.From
+	if retained := time.Now().Add(-storage.retention); from.Before(retained) {
+		from = retained
+	}
+
+	to := filter.To
+	if now := time.Now(); to.After(now) {
+		to = now
+	}
+
+	var minutes []time.Time
+	for minute := from.Truncate(time.Minute); !minute.After(to); minute = minute.Add(time.Minute) {
+		minutes = append(minutes, minute)
+	}
 
-		metrics[i] = entity.Metric{
-			Endpoint: strings.TrimPrefix(key, storage.prefix),
-			Count:    count,
+	pipe := storage.client.Pipeline()
+	commands := make([]*redis.MapStringStringCmd, len(minutes))
+	for i, minute := range minutes {
+		commands[i] = pipe.HGetAll(ctx, storage.key(minute))
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && len(minutes) > 0 {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	seriesByKey := make(map[string]*series)
+	for i, minute := range minutes {
+		point := int(minute.Sub(start) / filter.Granularity)
+
+		for field, rawValue := range commands[i].Val() {
+			parts := strings.SplitN(field, "|", 4)
+			if len(parts) != 4 {
+				continue
+			}
+
+			value, _ := strconv.ParseInt(rawValue, 10, 64)
+
+			key := parts[1] + "|" + parts[2] + "|" + parts[3]
+			s, ok := seriesByKey[key]
+			if !ok {
+				s = newSeries(parts[3], parts[1], parts[2], start, filter.Granularity, pointCount)
+				seriesByKey[key] = s
+			}
+
+			s.add(parts[0], point, value)
 		}
 	}
 
+	metrics := make(entity.Metrics, 0, len(seriesByKey))
+	for _, s := range seriesByKey {
+		metrics = append(metrics, s.metric())
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Endpoint != metrics[j].Endpoint {
+			return metrics[i].Endpoint < metrics[j].Endpoint
+		}
+
+		if metrics[i].Method != metrics[j].Method {
+			return metrics[i].Method < metrics[j].Method
+		}
+
+		return metrics[i].StatusClass < metrics[j].StatusClass
+	})
+
 	return metrics, nil
 }
+
+func (storage *metricsStorage) key(t time.Time) string {
+	return storage.prefix + strconv.FormatInt(t.Truncate(time.Minute).Unix(), 10)
+}
+
+// series накапливает значения одной комбинации маршрута, метода и класса статуса
+type series struct {
+	entity.Metric
+	buckets map[string]int
+}
+
+func newSeries(endpoint string, method string, class string, start time.Time, granularity time.Duration, pointCount int) *series {
+	s := &series{
+		Metric: entity.Metric{
+			Endpoint:    endpoint,
+			Method:      method,
+			StatusClass: class,
+			Points:      make([]entity.MetricPoint, pointCount),
+		},
+		buckets: make(map[string]int),
+	}
+
+	for i := range s.Points {
+		s.Points[i].Time = start.Add(time.Duration(i) * granularity)
+	}
+
+	return s
+}
+
+func (s *series) add(field string, point int, value int64) {
+	switch field {
+	case countField:
+		s.Count += int(value)
+		s.Points[point].Count += int(value)
+	case latencySumField:
+		latency := time.Duration(value) * time.Microsecond
+		s.LatencySum += latency
+		s.Points[point].LatencySum += latency
+	default:
+		s.buckets[field] += int(value)
+	}
+}
+
+// metric возвращает метрику с накопительными интервалами времени обработки
+func (s *series) metric() entity.Metric {
+	metric := s.Metric
+	metric.Buckets = make([]entity.LatencyBucket, 0, len(entity.LatencyBuckets)+1)
+
+	var cumulative int
+	for _, le := range entity.LatencyBuckets {
+		cumulative += s.buckets[le.String()]
+
+		metric.Buckets = append(metric.Buckets, entity.LatencyBucket{Le: le, Count: cumulative})
+	}
+
+	metric.Buckets = append(metric.Buckets, entity.LatencyBucket{Count: cumulative + s.buckets[infBucket]})
+
+	return metric
+}
+
+func bucket(duration time.Duration) string {
+	for _, le := range entity.LatencyBuckets {
+		if duration <= le {
+			return le.String()
+		}
+	}
+
+	return infBucket
+}
+
+func statusClass(status int) string {
+	return fmt.Sprintf("%dxx", status/100)
+}
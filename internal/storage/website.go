@@ -7,16 +7,59 @@ import (
 	"estimate/pkg/apperror"
 	"estimate/pkg/postgres"
 	"github.com/jackc/pgx/v5"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type WebsiteStorage interface {
+	Create(ctx context.Context, website entity.Website) (entity.Website, error)
+	GetByID(ctx context.Context, id int64) (entity.Website, error)
 	GetByURL(ctx context.Context, rawURL string) (entity.Website, error)
+	SelectByURLs(ctx context.Context, urls []string) ([]entity.Website, error)
 	Update(ctx context.Context, website entity.Website) error
+	Patch(ctx context.Context, website entity.Website) error
+	Delete(ctx context.Context, id int64) error
+	SetCertNotified(ctx context.Context, id int64, expiresAt time.Time) error
 	GetByMinAccessTime(ctx context.Context) (entity.Website, error)
 	GetByMaxAccessTime(ctx context.Context) (entity.Website, error)
 	Select(ctx context.Context) ([]entity.Website, error)
+	SelectPage(ctx context.Context, filter entity.WebsiteFilter) ([]entity.Website, error)
+	SelectTop(ctx context.Context, filter entity.TopFilter) ([]entity.RankedWebsite, error)
+	SelectByCertExpiresBefore(ctx context.Context, before time.Time) ([]entity.Website, error)
 }
 
+const websiteColumns = `id,
+       url,
+       last_check_at,
+       access_time,
+       status_code,
+       available,
+       checked_scheme,
+       error_class,
+       error_message,
+       state,
+       consecutive_failures,
+       consecutive_successes,
+       state_changed_at,
+       failing_since,
+       cert_expires_at,
+       cert_notified_expires_at,
+       dns_time,
+       connect_time,
+       tls_time,
+       ttfb_time,
+       transfer_time,
+       scheme,
+       method,
+       headers,
+       body,
+       accepted_statuses,
+       timeout,
+       follow_redirects,
+       max_redirects,
+       assertions`
+
 type websiteStorage struct {
 	client postgres.Client
 }
@@ -25,9 +68,59 @@ func NewWebsiteStorage(client postgres.Client) WebsiteStorage {
 	return &websiteStorage{client: client}
 }
 
+func (storage *websiteStorage) Create(ctx context.Context, website entity.Website) (entity.Website, error) {
+	q := `
+INSERT INTO website (url, scheme, method, headers, body, accepted_statuses, timeout, follow_redirects,
+                     max_redirects, assertions)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING ` + websiteColumns
+
+	err := storage.client.Get(ctx, &website, q,
+		website.URL,
+		website.Scheme,
+		website.Method,
+		website.Headers,
+		website.Body,
+		website.AcceptedStatuses,
+		website.Timeout,
+		website.FollowRedirects,
+		website.MaxRedirects,
+		website.Assertions,
+	)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return entity.Website{}, apperror.AlreadyExists.WithError(err)
+		}
+
+		return entity.Website{}, apperror.Internal.WithError(err)
+	}
+
+	return website, nil
+}
+
+func (storage *websiteStorage) GetByID(ctx context.Context, id int64) (entity.Website, error) {
+	q := `
+SELECT ` + websiteColumns + `
+FROM website
+WHERE id = $1
+`
+
+	var website entity.Website
+	err := storage.client.Get(ctx, &website, q, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.Website{}, apperror.NotFound.WithError(err)
+		}
+
+		return entity.Website{}, apperror.Internal.WithError(err)
+	}
+
+	return website, nil
+}
+
 func (storage *websiteStorage) GetByURL(ctx context.Context, rawURL string) (entity.Website, error) {
 	q := `
-SELECT url, last_check_at, access_time, status_code
+SELECT ` + websiteColumns + `
 FROM website
 WHERE url = $1
 `
@@ -45,31 +138,157 @@ WHERE url = $1
 	return website, nil
 }
 
+func (storage *websiteStorage) SelectByURLs(ctx context.Context, urls []string) ([]entity.Website, error) {
+	q := `
+SELECT ` + websiteColumns + `
+FROM website
+WHERE url = ANY ($1)
+`
+
+	var websites []entity.Website
+	err := storage.client.Select(ctx, &websites, q, urls)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return websites, nil
+}
+
 func (storage *websiteStorage) Update(ctx context.Context, website entity.Website) error {
 	q := `
 UPDATE website
 SET last_check_at = $1,
     access_time = $2,
-    status_code = $3
-WHERE url = $4
+    status_code = $3,
+    available = $4,
+    checked_scheme = $5,
+    error_class = $6,
+    error_message = $7,
+    state = $8,
+    consecutive_failures = $9,
+    consecutive_successes = $10,
+    state_changed_at = $11,
+    cert_expires_at = $12,
+    dns_time = $13,
+    connect_time = $14,
+    tls_time = $15,
+    ttfb_time = $16,
+    transfer_time = $17,
+    failing_since = $18
+WHERE id = $19
+`
+
+	_, err := storage.client.Exec(ctx, q,
+		website.LastCheckAt,
+		website.AccessTime,
+		website.StatusCode,
+		website.Available,
+		website.CheckedScheme,
+		website.ErrorClass,
+		website.ErrorMessage,
+		website.State,
+		website.ConsecutiveFailures,
+		website.ConsecutiveSuccesses,
+		website.StateChangedAt,
+		website.CertExpiresAt,
+		website.DNS,
+		website.Connect,
+		website.TLS,
+		website.TTFB,
+		website.Transfer,
+		website.FailingSince,
+		website.ID,
+	)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
+
+func (storage *websiteStorage) Patch(ctx context.Context, website entity.Website) error {
+	q := `
+UPDATE website
+SET url = $1,
+    scheme = $2,
+    method = $3,
+    headers = $4,
+    body = $5,
+    accepted_statuses = $6,
+    timeout = $7,
+    follow_redirects = $8,
+    max_redirects = $9,
+    assertions = $10
+WHERE id = $11
+`
+
+	tag, err := storage.client.Exec(ctx, q,
+		website.URL,
+		website.Scheme,
+		website.Method,
+		website.Headers,
+		website.Body,
+		website.AcceptedStatuses,
+		website.Timeout,
+		website.FollowRedirects,
+		website.MaxRedirects,
+		website.Assertions,
+		website.ID,
+	)
+	if err != nil {
+		if postgres.IsUniqueViolation(err) {
+			return apperror.AlreadyExists.WithError(err)
+		}
+
+		return apperror.Internal.WithError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound
+	}
+
+	return nil
+}
+
+func (storage *websiteStorage) SetCertNotified(ctx context.Context, id int64, expiresAt time.Time) error {
+	q := `
+UPDATE website
+SET cert_notified_expires_at = $1
+WHERE id = $2
+`
+
+	_, err := storage.client.Exec(ctx, q, expiresAt, id)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return nil
+}
+
+func (storage *websiteStorage) Delete(ctx context.Context, id int64) error {
+	q := `
+DELETE
+FROM website
+WHERE id = $1
 `
 
-	_, err := storage.client.Exec(ctx, q, website.LastCheckAt, website.AccessTime, website.StatusCode, website.URL)
+	tag, err := storage.client.Exec(ctx, q, id)
 	if err != nil {
 		return apperror.Internal.WithError(err)
 	}
 
+	if tag.RowsAffected() == 0 {
+		return apperror.NotFound
+	}
+
 	return nil
 }
 
 func (storage *websiteStorage) GetByMinAccessTime(ctx context.Context) (entity.Website, error) {
 	q := `
-SELECT url,
-       last_check_at,
-       access_time,
-       status_code
+SELECT ` + websiteColumns + `
 FROM website
-WHERE status_code = 200
+WHERE available
 ORDER BY access_time
 LIMIT 1
 `
@@ -89,12 +308,9 @@ LIMIT 1
 
 func (storage *websiteStorage) GetByMaxAccessTime(ctx context.Context) (entity.Website, error) {
 	q := `
-SELECT url,
-       last_check_at,
-       access_time,
-       status_code
+SELECT ` + websiteColumns + `
 FROM website
-WHERE status_code = 200
+WHERE available
 ORDER BY access_time DESC
 LIMIT 1
 `
@@ -114,11 +330,9 @@ LIMIT 1
 
 func (storage *websiteStorage) Select(ctx context.Context) ([]entity.Website, error) {
 	q := `
-SELECT url,
-       last_check_at,
-       access_time,
-       status_code
+SELECT ` + websiteColumns + `
 FROM website
+ORDER BY id
 `
 
 	var websites []entity.Website
@@ -133,3 +347,156 @@ FROM website
 
 	return websites, nil
 }
+
+// SelectPage возвращает до filter.Limit сайтов, отобранных и отсортированных в запросе,
+// страница начинается после filter.After
+func (storage *websiteStorage) SelectPage(ctx context.Context, filter entity.WebsiteFilter) ([]entity.Website, error) {
+	var where whereClause
+
+	if filter.StatusCode != 0 {
+		where.add("status_code = " + where.arg(filter.StatusCode))
+	}
+
+	if filter.Available != nil {
+		where.add("available = " + where.arg(*filter.Available))
+	}
+
+	if filter.URLPrefix != "" {
+		where.add("url LIKE " + where.arg(escapeLike(filter.URLPrefix)+"%"))
+	}
+
+	if !filter.CheckedBefore.IsZero() {
+		where.add("last_check_at < " + where.arg(filter.CheckedBefore))
+	}
+
+	column := string(entity.WebsiteSortURL)
+	var value any
+	switch filter.Sort {
+	case entity.WebsiteSortAccessTime:
+		column = string(entity.WebsiteSortAccessTime)
+		if filter.After != nil {
+			value = filter.After.AccessTime
+		}
+	case entity.WebsiteSortLastCheckAt:
+		column = string(entity.WebsiteSortLastCheckAt)
+		if filter.After != nil {
+			value = filter.After.LastCheckAt
+		}
+	default:
+		if filter.After != nil {
+			value = filter.After.URL
+		}
+	}
+
+	direction, comparison := "", ">"
+	if filter.Desc {
+		direction, comparison = " DESC", "<"
+	}
+
+	if filter.After != nil {
+		where.add("(" + column + ", id) " + comparison + " (" + where.arg(value) + ", " + where.arg(filter.After.ID) + ")")
+	}
+
+	q := `
+SELECT ` + websiteColumns + `
+FROM website
+` + where.String() + `
+ORDER BY ` + column + direction + `, id` + direction + `
+LIMIT ` + where.arg(filter.Limit)
+
+	var websites []entity.Website
+	err := storage.client.Select(ctx, &websites, q, where.args...)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return websites, nil
+}
+
+// SelectTop возвращает до filter.Limit доступных сайтов, упорядоченных по времени доступа,
+// место сайта считается среди всех сайтов, прошедших фильтры, сайты с равным временем делят место
+func (storage *websiteStorage) SelectTop(ctx context.Context, filter entity.TopFilter) ([]entity.RankedWebsite, error) {
+	var where whereClause
+	where.add("available")
+
+	// адрес хранится вместе с нестандартным портом, поэтому домен сравнивается без порта
+	if filter.TLD != "" {
+		where.add("split_part(url, ':', 1) LIKE " + where.arg("%."+escapeLike(filter.TLD)))
+	}
+
+	if filter.Pattern != "" {
+		where.add("url LIKE " + where.arg(strings.ReplaceAll(escapeLike(filter.Pattern), "*", "%")))
+	}
+
+	if filter.StatusCode != 0 {
+		where.add("status_code = " + where.arg(filter.StatusCode))
+	}
+
+	direction := ""
+	if filter.Desc {
+		direction = " DESC"
+	}
+
+	q := `
+SELECT RANK() OVER (ORDER BY access_time` + direction + `) AS rank,
+       ` + websiteColumns + `
+FROM website
+` + where.String() + `
+ORDER BY access_time` + direction + `, id
+LIMIT ` + where.arg(filter.Limit)
+
+	var websites []entity.RankedWebsite
+	err := storage.client.Select(ctx, &websites, q, where.args...)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return websites, nil
+}
+
+func (storage *websiteStorage) SelectByCertExpiresBefore(ctx context.Context, before time.Time) ([]entity.Website, error) {
+	q := `
+SELECT ` + websiteColumns + `
+FROM website
+WHERE cert_expires_at < $1
+ORDER BY cert_expires_at
+`
+
+	var websites []entity.Website
+	err := storage.client.Select(ctx, &websites, q, before)
+	if err != nil {
+		return nil, apperror.Internal.WithError(err)
+	}
+
+	return websites, nil
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE, чтобы префикс сравнивался буквально
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// whereClause собирает условия запроса с позиционными параметрами
+type whereClause struct {
+	conditions []string
+	args       []any
+}
+
+// arg добавляет параметр и возвращает его placeholder
+func (where *whereClause) arg(value any) string {
+	where.args = append(where.args, value)
+
+	return "$" + strconv.Itoa(len(where.args))
+}
+
+func (where *whereClause) add(condition string) {
+	where.conditions = append(where.conditions, condition)
+}
+
+func (where *whereClause) String() string {
+	if len(where.conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(where.conditions, "\n  AND ")
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/internal/service"
+	estimatev1 "estimate/pkg/api/estimate/v1"
+	"estimate/pkg/apperror"
+	"estimate/pkg/ratelimit"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"math"
+	"strconv"
+)
+
+type estimateServer struct {
+	estimatev1.UnimplementedEstimateServiceServer
+	websiteService service.WebsiteService
+	resultService  service.ResultService
+	limiter        ratelimit.Limiter
+	liveIP         ratelimit.Limit
+	liveKey        ratelimit.Limit
+}
+
+func (server *estimateServer) GetEstimate(ctx context.Context, request *estimatev1.GetEstimateRequest) (*estimatev1.Estimate, error) {
+	// проверка неизвестного сайта учитывается в тех же лимитах, что и в REST API
+	ctx = service.WithLiveCheckLimit(ctx, func() error {
+		return server.allowLiveCheck(ctx)
+	})
+
+	website, err := server.websiteService.GetByURL(ctx, request.GetUrl())
+	if err != nil {
+		return nil, err
+	}
+
+	return newEstimate(website), nil
+}
+
+func (server *estimateServer) GetMin(ctx context.Context, _ *estimatev1.GetMinRequest) (*estimatev1.Estimate, error) {
+	website, err := server.websiteService.GetByMinAccessTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEstimate(website), nil
+}
+
+func (server *estimateServer) GetMax(ctx context.Context, _ *estimatev1.GetMaxRequest) (*estimatev1.Estimate, error) {
+	website, err := server.websiteService.GetByMaxAccessTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newEstimate(website), nil
+}
+
+func (server *estimateServer) ListWebsites(ctx context.Context, _ *estimatev1.ListWebsitesRequest) (*estimatev1.ListWebsitesResponse, error) {
+	websites, err := server.websiteService.Select(ctx)
+	if err != nil && !errors.Is(err, apperror.NotFound) {
+		return nil, err
+	}
+
+	response := &estimatev1.ListWebsitesResponse{
+		Websites: make([]*estimatev1.Website, len(websites)),
+	}
+	for i, website := range websites {
+		response.Websites[i] = newWebsite(website)
+	}
+
+	return response, nil
+}
+
+// WatchResults отправляет результаты проверок, пока клиент не отменит вызов,
+// при закрытии подписки сервером клиент может продолжить поток с последнего ID
+func (server *estimateServer) WatchResults(request *estimatev1.WatchResultsRequest, stream estimatev1.EstimateService_WatchResultsServer) error {
+	results, err := server.resultService.Subscribe(stream.Context(), entity.ResultFilter{
+		URLs:  request.GetUrls(),
+		After: request.GetAfterId(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for result := range results {
+		err = stream.Send(newResult(result))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = stream.Context().Err(); err != nil {
+		return err
+	}
+
+	return apperror.Unavailable.WithMessage("result stream closed")
+}
+
+func (server *estimateServer) allowLiveCheck(ctx context.Context) error {
+	result, err := ratelimit.AllowClient(ctx, server.limiter, "live", peerIP(ctx), apiKey(ctx), server.liveIP, server.liveKey)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	if !result.Allowed {
+		return apperror.TooManyRequests.WithMessage("rate limit exceeded, retry in " + strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))) + "s")
+	}
+
+	return nil
+}
+
+func newPhases(phases entity.Phases) *estimatev1.Phases {
+	return &estimatev1.Phases{
+		Dns:      durationpb.New(phases.DNS),
+		Connect:  durationpb.New(phases.Connect),
+		Tls:      durationpb.New(phases.TLS),
+		Ttfb:     durationpb.New(phases.TTFB),
+		Transfer: durationpb.New(phases.Transfer),
+	}
+}
+
+func newEstimate(website entity.Website) *estimatev1.Estimate {
+	return &estimatev1.Estimate{
+		Url:         website.URL,
+		Scheme:      website.CheckedScheme,
+		AccessTime:  durationpb.New(website.AccessTime),
+		Phases:      newPhases(website.Phases),
+		LastCheckAt: timestamppb.New(website.LastCheckAt),
+	}
+}
+
+func newWebsite(website entity.Website) *estimatev1.Website {
+	return &estimatev1.Website{
+		Id:            website.ID,
+		Url:           website.URL,
+		State:         string(website.State),
+		Available:     website.Available,
+		StatusCode:    int32(website.StatusCode),
+		CheckedScheme: website.CheckedScheme,
+		AccessTime:    durationpb.New(website.AccessTime),
+		Phases:        newPhases(website.Phases),
+		LastCheckAt:   timestamppb.New(website.LastCheckAt),
+		ErrorClass:    string(website.ErrorClass),
+		ErrorMessage:  website.ErrorMessage,
+	}
+}
+
+func newResult(result entity.Result) *estimatev1.Result {
+	return &estimatev1.Result{
+		Id:    result.ID,
+		Url:   result.Website.URL,
+		State: string(result.Website.State),
+		Check: &estimatev1.Check{
+			CheckedAt:       timestamppb.New(result.Check.CheckedAt),
+			AccessTime:      durationpb.New(result.Check.AccessTime),
+			Phases:          newPhases(result.Check.Phases),
+			StatusCode:      int32(result.Check.StatusCode),
+			Scheme:          result.Check.Scheme,
+			Available:       result.Check.Available,
+			FailedAssertion: result.Check.FailedAssertion,
+			ErrorClass:      string(result.Check.ErrorClass),
+			ErrorMessage:    result.Check.ErrorMessage,
+		},
+	}
+}
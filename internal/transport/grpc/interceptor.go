@@ -0,0 +1,173 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"estimate/internal/entity"
+	"estimate/internal/service"
+	"estimate/pkg/apperror"
+	"estimate/pkg/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"math"
+	"net"
+	"strconv"
+)
+
+// APIKeyMetadata ключ метаданных с API ключом, аналог заголовка X-API-Key
+const APIKeyMetadata = "x-api-key"
+
+// authenticator проверяет API ключ из метаданных, если API не публичное, ключ обязателен
+// и должен иметь право estimates:read, неудачные попытки учитываются в failures так же, как в REST
+type authenticator struct {
+	apiKeyService service.APIKeyService
+	publicAPI     bool
+	failures      *ratelimit.Failures
+}
+
+func (authenticator *authenticator) unary(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	err := authenticator.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+func (authenticator *authenticator) stream(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := authenticator.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, stream)
+}
+
+func (authenticator *authenticator) authenticate(ctx context.Context) error {
+	rawKey := apiKey(ctx)
+	if rawKey == "" {
+		if authenticator.publicAPI {
+			return nil
+		}
+
+		return apperror.Unauthorized.WithMessage("api key required")
+	}
+
+	ip := peerIP(ctx)
+	result, err := authenticator.failures.Check(ctx, ip)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	if !result.Allowed {
+		return apperror.TooManyRequests.WithMessage("too many failed authentication attempts, retry in " + strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))) + "s")
+	}
+
+	key, err := authenticator.apiKeyService.Authenticate(ctx, rawKey)
+	if err != nil {
+		if _, ok := apperror.Is(err, apperror.Unauthorized); ok {
+			failureErr := authenticator.failures.Add(ctx, ip)
+			if failureErr != nil {
+				return apperror.Internal.WithError(failureErr)
+			}
+		}
+
+		return err
+	}
+
+	if !authenticator.publicAPI && !key.Allows(entity.ScopeEstimatesRead) {
+		return apperror.Forbidden.WithMessage("api key has no " + string(entity.ScopeEstimatesRead) + " scope")
+	}
+
+	return nil
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	ip, _, _ := net.SplitHostPort(p.Addr.String())
+
+	return ip
+}
+
+func apiKey(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, APIKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func unaryErrors(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, statusError(log, err)
+		}
+
+		return resp, nil
+	}
+}
+
+func streamErrors(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, stream)
+		if err != nil {
+			return statusError(log, err)
+		}
+
+		return nil
+	}
+}
+
+// statusError переводит apperror в статус gRPC так же, как middleware.Error переводит его в статус HTTP
+func statusError(log *zap.Logger, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var apperr apperror.Error
+	if !errors.As(err, &apperr) {
+		log.Warn("unexpected error", zap.Error(err))
+
+		return status.Error(codes.Unknown, err.Error())
+	}
+
+	var code codes.Code
+	switch apperr.Code {
+	case apperror.Internal.Code:
+		log.Error("internal error", zap.Error(err))
+		code = codes.Internal
+	case apperror.NotFound.Code:
+		code = codes.NotFound
+	case apperror.AlreadyExists.Code:
+		code = codes.AlreadyExists
+	case apperror.BadRequest.Code:
+		code = codes.InvalidArgument
+	case apperror.Unauthorized.Code:
+		code = codes.Unauthenticated
+	case apperror.Forbidden.Code:
+		code = codes.PermissionDenied
+	case apperror.Unavailable.Code:
+		code = codes.Unavailable
+	case apperror.TooManyRequests.Code:
+		code = codes.ResourceExhausted
+	default:
+		code = codes.Unknown
+	}
+
+	message := apperr.Message
+	if message == "" {
+		message = apperr.Status
+	}
+
+	return status.Error(code, message)
+}
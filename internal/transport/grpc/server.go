@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"errors"
+	"estimate/internal/config"
+	"estimate/internal/service"
+	estimatev1 "estimate/pkg/api/estimate/v1"
+	"estimate/pkg/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"net"
+)
+
+type Server struct {
+	server *grpc.Server
+	conf   config.GRPC
+}
+
+func New(
+	conf config.GRPC,
+	publicAPI bool,
+	websiteService service.WebsiteService,
+	resultService service.ResultService,
+	apiKeyService service.APIKeyService,
+	limiter ratelimit.Limiter,
+	authFailures *ratelimit.Failures,
+	liveIP ratelimit.Limit,
+	liveKey ratelimit.Limit,
+	log *zap.Logger,
+) *Server {
+	auth := &authenticator{
+		apiKeyService: apiKeyService,
+		publicAPI:     publicAPI,
+		failures:      authFailures,
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryErrors(log), auth.unary),
+		grpc.ChainStreamInterceptor(streamErrors(log), auth.stream),
+	)
+
+	estimatev1.RegisterEstimateServiceServer(server, &estimateServer{
+		websiteService: websiteService,
+		resultService:  resultService,
+		limiter:        limiter,
+		liveIP:         liveIP,
+		liveKey:        liveKey,
+	})
+
+	return &Server{
+		server: server,
+		conf:   conf,
+	}
+}
+
+func (server *Server) Listen() error {
+	listener, err := net.Listen("tcp", server.conf.Addr)
+	if err != nil {
+		return err
+	}
+
+	err = server.server.Serve(listener)
+	if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown дожидается завершения текущих вызовов, потоки WatchResults
+// нужно закрыть заранее через ResultService.Close
+func (server *Server) Shutdown() {
+	server.server.GracefulStop()
+}
@@ -1,27 +1,429 @@
 package handler
 
 import (
+	"estimate/internal/dto"
+	"estimate/internal/entity"
 	"estimate/internal/service"
+	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/apperror"
 	"github.com/gofiber/fiber/v2"
+	"time"
 )
 
 type AdminHandler struct {
-	metricsService service.MetricsService
+	metricsService      service.MetricsService
+	websiteService      service.WebsiteService
+	notificationService service.NotificationService
+	apiKeyService       service.APIKeyService
 }
 
-func NewAdminHandler(metricsService service.MetricsService) *AdminHandler {
-	return &AdminHandler{metricsService: metricsService}
+func NewAdminHandler(
+	metricsService service.MetricsService,
+	websiteService service.WebsiteService,
+	notificationService service.NotificationService,
+	apiKeyService service.APIKeyService,
+) *AdminHandler {
+	return &AdminHandler{
+		metricsService:      metricsService,
+		websiteService:      websiteService,
+		notificationService: notificationService,
+		apiKeyService:       apiKeyService,
+	}
 }
 
 func (handler *AdminHandler) Register(router fiber.Router) {
-	router.Get("/metrics", handler.Metrics)
+	router.Get("/metrics", middleware.RequireScope(entity.ScopeMetricsRead), handler.Metrics)
+
+	websites := router.Group("/websites", middleware.RequireScope(entity.ScopeWebsitesWrite))
+	{
+		websites.Post("", handler.CreateWebsite)
+		websites.Get("", handler.SelectWebsites)
+		websites.Get("/:id", handler.GetWebsite)
+		websites.Patch("/:id", handler.PatchWebsite)
+		websites.Delete("/:id", handler.DeleteWebsite)
+	}
+
+	router.Get("/certificates/expiring", middleware.RequireScope(entity.ScopeWebsitesWrite), handler.SelectExpiringCertificates)
+
+	channels := router.Group("/channels", middleware.RequireScope(entity.ScopeAdmin))
+	{
+		channels.Post("", handler.CreateChannel)
+		channels.Get("", handler.SelectChannels)
+		channels.Get("/:id", handler.GetChannel)
+		channels.Patch("/:id", handler.PatchChannel)
+		channels.Delete("/:id", handler.DeleteChannel)
+		channels.Post("/:id/test", handler.TestChannel)
+	}
+
+	keys := router.Group("/keys", middleware.RequireScope(entity.ScopeAdmin))
+	{
+		keys.Post("", handler.CreateAPIKey)
+		keys.Get("", handler.SelectAPIKeys)
+		keys.Delete("/:id", handler.RevokeAPIKey)
+	}
 }
 
 func (handler *AdminHandler) Metrics(c *fiber.Ctx) error {
-	metrics, err := handler.metricsService.Metrics(c.Context())
+	var request dto.GetMetricsRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var filter entity.MetricsFilter
+	filter, err = request.Filter()
+	if err != nil {
+		return err
+	}
+
+	var metrics entity.Metrics
+	metrics, err = handler.metricsService.Metrics(c.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.MetricResponse, len(metrics))
+	for i, metric := range metrics {
+		response[i] = dto.NewMetricResponse(metric)
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *AdminHandler) CreateWebsite(c *fiber.Ctx) error {
+	var request dto.CreateWebsiteRequest
+	err := c.BodyParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid body")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	checkConfig := dto.DefaultCheckConfig()
+	if scheme, ok := service.ExplicitScheme(request.URL); ok {
+		checkConfig.Scheme = scheme
+	}
+
+	website := entity.Website{
+		URL:         request.URL,
+		CheckConfig: request.CheckConfig.Apply(checkConfig),
+	}
+
+	website, err = handler.websiteService.Create(c.Context(), website)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.NewWebsiteResponse(website))
+}
+
+func (handler *AdminHandler) SelectWebsites(c *fiber.Ctx) error {
+	websites, err := handler.websiteService.Select(c.Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.WebsiteResponse, len(websites))
+	for i, website := range websites {
+		response[i] = dto.NewWebsiteResponse(website)
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *AdminHandler) GetWebsite(c *fiber.Ctx) error {
+	id, err := websiteID(c)
+	if err != nil {
+		return err
+	}
+
+	var website entity.Website
+	website, err = handler.websiteService.GetByID(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewWebsiteResponse(website))
+}
+
+func (handler *AdminHandler) PatchWebsite(c *fiber.Ctx) error {
+	id, err := websiteID(c)
+	if err != nil {
+		return err
+	}
+
+	var request dto.PatchWebsiteRequest
+	err = c.BodyParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid body")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var website entity.Website
+	website, err = handler.websiteService.GetByID(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	if request.URL != nil {
+		website.URL = *request.URL
+
+		if scheme, ok := service.ExplicitScheme(website.URL); ok {
+			website.Scheme = scheme
+		}
+	}
+	website.CheckConfig = request.CheckConfig.Apply(website.CheckConfig)
+
+	website, err = handler.websiteService.Patch(c.Context(), website)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewWebsiteResponse(website))
+}
+
+func (handler *AdminHandler) DeleteWebsite(c *fiber.Ctx) error {
+	id, err := websiteID(c)
+	if err != nil {
+		return err
+	}
+
+	err = handler.websiteService.Delete(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func websiteID(c *fiber.Ctx) (int64, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return 0, apperror.BadRequest.WithMessage("invalid website id")
+	}
+
+	return int64(id), nil
+}
+
+func (handler *AdminHandler) SelectExpiringCertificates(c *fiber.Ctx) error {
+	var request dto.GetExpiringCertificatesRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	if request.Days == 0 {
+		request.Days = dto.DefaultExpiringDays
+	}
+
+	var websites []entity.Website
+	websites, err = handler.websiteService.SelectByCertExpiresWithin(c.Context(), time.Duration(request.Days)*24*time.Hour)
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.ExpiringCertificateResponse, len(websites))
+	for i, website := range websites {
+		response[i] = dto.ExpiringCertificateResponse{
+			ID:        website.ID,
+			URL:       website.URL,
+			ExpiresAt: *website.CertExpiresAt,
+			DaysLeft:  dto.DaysLeft(*website.CertExpiresAt),
+		}
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *AdminHandler) CreateChannel(c *fiber.Ctx) error {
+	var request dto.CreateChannelRequest
+	err := c.BodyParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid body")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var channel entity.Channel
+	channel, err = handler.notificationService.CreateChannel(c.Context(), request.Channel())
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.NewChannelResponse(channel))
+}
+
+func (handler *AdminHandler) SelectChannels(c *fiber.Ctx) error {
+	channels, err := handler.notificationService.SelectChannels(c.Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.ChannelResponse, len(channels))
+	for i, channel := range channels {
+		response[i] = dto.NewChannelResponse(channel)
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *AdminHandler) GetChannel(c *fiber.Ctx) error {
+	id, err := channelID(c)
+	if err != nil {
+		return err
+	}
+
+	var channel entity.Channel
+	channel, err = handler.notificationService.GetChannel(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewChannelResponse(channel))
+}
+
+func (handler *AdminHandler) PatchChannel(c *fiber.Ctx) error {
+	id, err := channelID(c)
+	if err != nil {
+		return err
+	}
+
+	var request dto.PatchChannelRequest
+	err = c.BodyParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid body")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var channel entity.Channel
+	channel, err = handler.notificationService.GetChannel(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	channel, err = handler.notificationService.UpdateChannel(c.Context(), request.Apply(channel))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewChannelResponse(channel))
+}
+
+func (handler *AdminHandler) DeleteChannel(c *fiber.Ctx) error {
+	id, err := channelID(c)
+	if err != nil {
+		return err
+	}
+
+	err = handler.notificationService.DeleteChannel(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// TestChannel отправляет в канал тестовое событие, ошибка доставки возвращается в ответе
+func (handler *AdminHandler) TestChannel(c *fiber.Ctx) error {
+	id, err := channelID(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = handler.notificationService.GetChannel(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	err = handler.notificationService.Test(c.Context(), id)
+	if err != nil {
+		return c.JSON(dto.ChannelTestResponse{Error: err.Error()})
+	}
+
+	return c.JSON(dto.ChannelTestResponse{Delivered: true})
+}
+
+func channelID(c *fiber.Ctx) (int64, error) {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return 0, apperror.BadRequest.WithMessage("invalid channel id")
+	}
+
+	return int64(id), nil
+}
+
+// CreateAPIKey выпускает ключ, сам ключ возвращается только в этом ответе
+func (handler *AdminHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var request dto.CreateAPIKeyRequest
+	err := c.BodyParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid body")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	key, rawKey, err := handler.apiKeyService.Issue(c.Context(), request.Name, request.Scopes)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.CreateAPIKeyResponse{
+		APIKeyResponse: dto.NewAPIKeyResponse(key),
+		Key:            rawKey,
+	})
+}
+
+func (handler *AdminHandler) SelectAPIKeys(c *fiber.Ctx) error {
+	keys, err := handler.apiKeyService.Select(c.Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.APIKeyResponse, len(keys))
+	for i, key := range keys {
+		response[i] = dto.NewAPIKeyResponse(key)
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *AdminHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return apperror.BadRequest.WithMessage("invalid api key id")
+	}
+
+	err = handler.apiKeyService.Revoke(c.Context(), int64(id))
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(metrics)
+	return c.SendStatus(fiber.StatusNoContent)
 }
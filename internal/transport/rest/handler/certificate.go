@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"estimate/internal/dto"
+	"estimate/internal/entity"
+	"estimate/internal/service"
+	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/apperror"
+	"github.com/alejandro-carstens/gocache"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+type CertificateHandler struct {
+	checkService service.CheckService
+	cache        gocache.TaggedCache
+}
+
+func NewCertificateHandler(checkService service.CheckService, cache gocache.TaggedCache) *CertificateHandler {
+	return &CertificateHandler{
+		checkService: checkService,
+		cache:        cache,
+	}
+}
+
+func (handler *CertificateHandler) Register(router fiber.Router) {
+	cacheMiddleware := middleware.Cache(1*time.Minute, handler.cache)
+
+	router.Get("", cacheMiddleware, handler.GetCertificate)
+}
+
+func (handler *CertificateHandler) GetCertificate(c *fiber.Ctx) error {
+	var request dto.GetCertificateRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var check entity.Check
+	check, err = handler.checkService.Certificate(c.Context(), request.URL)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewGetCertificateResponse(request.URL, check))
+}
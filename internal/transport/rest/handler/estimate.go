@@ -1,24 +1,44 @@
 package handler
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
 	"estimate/internal/dto"
 	"estimate/internal/entity"
 	"estimate/internal/service"
 	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/apperror"
 	"github.com/alejandro-carstens/gocache"
 	"github.com/gofiber/fiber/v2"
+	"strconv"
 	"time"
 )
 
+// streamHeartbeat интервал комментариев, которые не дают прокси закрыть неактивный поток
+const streamHeartbeat = 15 * time.Second
+
 type EstimateHandler struct {
 	websiteService service.WebsiteService
+	checkService   service.CheckService
+	resultService  service.ResultService
 	cache          gocache.TaggedCache
+	liveLimiter    *middleware.RateLimiter
 }
 
-func NewEstimateHandler(websiteService service.WebsiteService, cache gocache.TaggedCache) *EstimateHandler {
+func NewEstimateHandler(
+	websiteService service.WebsiteService,
+	checkService service.CheckService,
+	resultService service.ResultService,
+	cache gocache.TaggedCache,
+	liveLimiter *middleware.RateLimiter,
+) *EstimateHandler {
 	return &EstimateHandler{
 		websiteService: websiteService,
+		checkService:   checkService,
+		resultService:  resultService,
 		cache:          cache,
+		liveLimiter:    liveLimiter,
 	}
 }
 
@@ -26,8 +46,14 @@ func (handler *EstimateHandler) Register(router fiber.Router) {
 	cacheMiddleware := middleware.Cache(1*time.Minute, handler.cache)
 
 	router.Get("", cacheMiddleware, handler.CheckWebsite)
+	router.Post("/batch", handler.CheckWebsites)
 	router.Get("/max", cacheMiddleware, handler.GetWebsiteByMaxAccessTime)
 	router.Get("/min", cacheMiddleware, handler.GetWebsiteByMinAccessTime)
+	router.Get("/top", cacheMiddleware, handler.GetTopWebsites)
+	router.Get("/history", cacheMiddleware, handler.GetWebsiteHistory)
+	router.Get("/stats", cacheMiddleware, handler.GetWebsiteStats)
+	router.Get("/redirects", cacheMiddleware, handler.GetWebsiteRedirects)
+	router.Get("/stream", handler.Stream)
 }
 
 func (handler *EstimateHandler) CheckWebsite(c *fiber.Ctx) error {
@@ -42,18 +68,59 @@ func (handler *EstimateHandler) CheckWebsite(c *fiber.Ctx) error {
 		return err
 	}
 
+	// проверка неизвестного сайта выполняется в момент запроса, поэтому для нее действует отдельный лимит
+	ctx := service.WithLiveCheckLimit(c.Context(), func() error {
+		return handler.liveLimiter.Allow(c)
+	})
+
 	var website entity.Website
-	website, err = handler.websiteService.GetByURL(c.Context(), request.URL)
+	website, err = handler.websiteService.GetByURL(ctx, request.URL)
 	if err != nil {
 		return err
 	}
 
 	return c.JSON(dto.GetWebsiteAccessTimeResponse{
+		Scheme:      website.CheckedScheme,
 		AccessTime:  dto.Duration{Duration: website.AccessTime},
+		Phases:      dto.NewPhases(website.Phases),
 		LastCheckAt: website.LastCheckAt,
 	})
 }
 
+func (handler *EstimateHandler) CheckWebsites(c *fiber.Ctx) error {
+	var request dto.GetBatchRequest
+	err := c.BodyParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid body")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	// каждая проверка неизвестного сайта учитывается в лимите отдельно, превышение лимита
+	// возвращается в результате по этой ссылке, поэтому заголовки ответа не меняются
+	ctx := service.WithLiveCheckLimit(c.Context(), func() error {
+		return handler.liveLimiter.Take(c)
+	})
+
+	var estimates []entity.Estimate
+	estimates, err = handler.websiteService.GetByURLs(ctx, request.URLs)
+	if err != nil {
+		return err
+	}
+
+	response := dto.GetBatchResponse{
+		Results: make([]dto.BatchResultResponse, len(estimates)),
+	}
+	for i, estimate := range estimates {
+		response.Results[i] = dto.NewBatchResultResponse(estimate)
+	}
+
+	return c.JSON(response)
+}
+
 func (handler *EstimateHandler) GetWebsiteByMaxAccessTime(c *fiber.Ctx) error {
 	website, err := handler.websiteService.GetByMaxAccessTime(c.Context())
 	if err != nil {
@@ -62,7 +129,9 @@ func (handler *EstimateHandler) GetWebsiteByMaxAccessTime(c *fiber.Ctx) error {
 
 	return c.JSON(dto.GetWebsiteWithMaxAccessTimeResponse{
 		URL:         website.URL,
+		Scheme:      website.CheckedScheme,
 		AccessTime:  dto.Duration{Duration: website.AccessTime},
+		Phases:      dto.NewPhases(website.Phases),
 		LastCheckAt: website.LastCheckAt,
 	})
 }
@@ -75,7 +144,195 @@ func (handler *EstimateHandler) GetWebsiteByMinAccessTime(c *fiber.Ctx) error {
 
 	return c.JSON(dto.GetWebsiteWithMinAccessTimeResponse{
 		URL:         website.URL,
+		Scheme:      website.CheckedScheme,
 		AccessTime:  dto.Duration{Duration: website.AccessTime},
+		Phases:      dto.NewPhases(website.Phases),
 		LastCheckAt: website.LastCheckAt,
 	})
 }
+
+func (handler *EstimateHandler) GetTopWebsites(c *fiber.Ctx) error {
+	var request dto.GetTopRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var websites []entity.RankedWebsite
+	websites, err = handler.websiteService.SelectTop(c.Context(), request.Filter())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.TopWebsiteResponse, len(websites))
+	for i, website := range websites {
+		response[i] = dto.NewTopWebsiteResponse(website)
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *EstimateHandler) GetWebsiteHistory(c *fiber.Ctx) error {
+	var request dto.GetWebsiteHistoryRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	if request.To.IsZero() {
+		request.To = time.Now()
+	}
+
+	if request.From.IsZero() {
+		request.From = request.To.Add(-24 * time.Hour)
+	}
+
+	var checks []entity.Check
+	checks, err = handler.checkService.History(c.Context(), request.URL, request.From, request.To)
+	if err != nil {
+		return err
+	}
+
+	response := dto.GetWebsiteHistoryResponse{
+		URL:    request.URL,
+		From:   request.From,
+		To:     request.To,
+		Checks: make([]dto.CheckResponse, len(checks)),
+	}
+	for i, check := range checks {
+		response.Checks[i] = dto.NewCheckResponse(check)
+	}
+
+	return c.JSON(response)
+}
+
+func (handler *EstimateHandler) GetWebsiteStats(c *fiber.Ctx) error {
+	var request dto.GetWebsiteStatsRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	if request.Window == 0 {
+		request.Window = dto.DefaultStatsWindow
+	}
+
+	var stats entity.Stats
+	stats, err = handler.checkService.Stats(c.Context(), request.URL, request.Window)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewGetWebsiteStatsResponse(request.URL, request.Window, stats))
+}
+
+func (handler *EstimateHandler) GetWebsiteRedirects(c *fiber.Ctx) error {
+	var request dto.GetWebsiteRedirectsRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var check entity.Check
+	check, err = handler.checkService.Redirects(c.Context(), request.URL)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(dto.NewGetWebsiteRedirectsResponse(request.URL, check))
+}
+
+// Stream отправляет результаты проверок в формате Server-Sent Events,
+// при переподключении с заголовком Last-Event-ID сначала отправляются пропущенные результаты
+func (handler *EstimateHandler) Stream(c *fiber.Ctx) error {
+	var request dto.GetResultStreamRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	filter := entity.ResultFilter{URLs: request.URLs}
+	if lastEventID := c.Get("Last-Event-ID"); lastEventID != "" {
+		filter.After, err = strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return apperror.BadRequest.WithMessage("invalid last event id")
+		}
+	}
+
+	// поток живет дольше запроса, поэтому подписка отменяется, когда клиент перестает принимать данные
+	ctx, cancel := context.WithCancel(context.Background())
+
+	results, err := handler.resultService.Subscribe(ctx, filter)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+
+		heartbeat := time.NewTicker(streamHeartbeat)
+		defer heartbeat.Stop()
+
+		_, _ = w.WriteString(": connected\n\n")
+		if w.Flush() != nil {
+			return
+		}
+
+		for {
+			select {
+			case result, ok := <-results:
+				if !ok {
+					return
+				}
+
+				data, err := json.Marshal(dto.NewResultResponse(result))
+				if err != nil {
+					return
+				}
+
+				_, _ = w.WriteString("id: " + strconv.FormatUint(result.ID, 10) + "\nevent: result\ndata: ")
+				_, _ = w.Write(data)
+				_, _ = w.WriteString("\n\n")
+			case <-heartbeat.C:
+				_, _ = w.WriteString(": heartbeat\n\n")
+			}
+
+			if w.Flush() != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"estimate/internal/dto"
+	"estimate/internal/entity"
+	"estimate/internal/service"
+	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/apperror"
+	"github.com/alejandro-carstens/gocache"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+type IncidentHandler struct {
+	incidentService service.IncidentService
+	cache           gocache.TaggedCache
+}
+
+func NewIncidentHandler(incidentService service.IncidentService, cache gocache.TaggedCache) *IncidentHandler {
+	return &IncidentHandler{
+		incidentService: incidentService,
+		cache:           cache,
+	}
+}
+
+func (handler *IncidentHandler) Register(router fiber.Router) {
+	cacheMiddleware := middleware.Cache(1*time.Minute, handler.cache)
+
+	router.Get("", cacheMiddleware, handler.SelectIncidents)
+}
+
+func (handler *IncidentHandler) SelectIncidents(c *fiber.Ctx) error {
+	var request dto.GetIncidentsRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	if request.To.IsZero() {
+		request.To = time.Now()
+	}
+
+	if request.From.IsZero() {
+		request.From = request.To.Add(-7 * 24 * time.Hour)
+	}
+
+	var incidents []entity.Incident
+	incidents, err = handler.incidentService.Select(c.Context(), request.URL, request.From, request.To)
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.IncidentResponse, len(incidents))
+	for i, incident := range incidents {
+		response[i] = dto.NewIncidentResponse(incident)
+	}
+
+	return c.JSON(response)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"bytes"
+	"embed"
+	"estimate/internal/dto"
+	"estimate/internal/service"
+	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/apperror"
+	"github.com/alejandro-carstens/gocache"
+	"github.com/gofiber/fiber/v2"
+	"html/template"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+var statusTemplate = template.Must(template.ParseFS(templatesFS, "templates/status.html"))
+
+type StatusHandler struct {
+	websiteService service.WebsiteService
+	cache          gocache.TaggedCache
+	cacheTTL       time.Duration
+}
+
+func NewStatusHandler(websiteService service.WebsiteService, cache gocache.TaggedCache, cacheTTL time.Duration) *StatusHandler {
+	return &StatusHandler{
+		websiteService: websiteService,
+		cache:          cache,
+		cacheTTL:       cacheTTL,
+	}
+}
+
+func (handler *StatusHandler) Register(router fiber.Router) {
+	cacheControl := "public, max-age=" + strconv.Itoa(int(handler.cacheTTL.Seconds()))
+
+	router.Get("/status", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderCacheControl, cacheControl)
+
+		return c.Next()
+	}, middleware.Cache(handler.cacheTTL, handler.cache), handler.Status)
+}
+
+func (handler *StatusHandler) Status(c *fiber.Ctx) error {
+	websites, err := handler.websiteService.Select(c.Context())
+	if err != nil {
+		if _, ok := apperror.Is(err, apperror.NotFound); !ok {
+			return err
+		}
+	}
+
+	sort.Slice(websites, func(i, j int) bool {
+		return websites[i].URL < websites[j].URL
+	})
+
+	var buffer bytes.Buffer
+	err = statusTemplate.Execute(&buffer, dto.NewStatusPage(websites))
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return c.Type("html").Send(buffer.Bytes())
+}
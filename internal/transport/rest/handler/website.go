@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"estimate/internal/dto"
+	"estimate/internal/entity"
+	"estimate/internal/service"
+	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/apperror"
+	"github.com/alejandro-carstens/gocache"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+type WebsiteHandler struct {
+	websiteService service.WebsiteService
+	cache          gocache.TaggedCache
+}
+
+func NewWebsiteHandler(websiteService service.WebsiteService, cache gocache.TaggedCache) *WebsiteHandler {
+	return &WebsiteHandler{
+		websiteService: websiteService,
+		cache:          cache,
+	}
+}
+
+func (handler *WebsiteHandler) Register(router fiber.Router) {
+	cacheMiddleware := middleware.Cache(1*time.Minute, handler.cache)
+
+	router.Get("", cacheMiddleware, handler.SelectWebsites)
+}
+
+func (handler *WebsiteHandler) SelectWebsites(c *fiber.Ctx) error {
+	var request dto.GetWebsitesRequest
+	err := c.QueryParser(&request)
+	if err != nil {
+		return apperror.BadRequest.WithError(err).WithMessage("invalid query")
+	}
+
+	err = request.Validate()
+	if err != nil {
+		return err
+	}
+
+	var filter entity.WebsiteFilter
+	filter, err = request.Filter()
+	if err != nil {
+		return err
+	}
+
+	var page entity.WebsitePage
+	page, err = handler.websiteService.SelectPage(c.Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	response := dto.GetWebsitesResponse{
+		Websites: make([]dto.WebsiteSummaryResponse, len(page.Websites)),
+	}
+	for i, website := range page.Websites {
+		response.Websites[i] = dto.NewWebsiteSummaryResponse(website)
+	}
+
+	if page.Next != nil {
+		response.NextCursor = dto.EncodeCursor(filter, *page.Next)
+	}
+
+	return c.JSON(response)
+}
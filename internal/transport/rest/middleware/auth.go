@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"estimate/internal/entity"
+	"estimate/internal/service"
+	"estimate/pkg/apperror"
+	"estimate/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"strings"
+)
+
+const apiKeyLocal = "api_key"
+
+// Auth определяет клиента по заголовку X-API-Key, а при его отсутствии - по basic auth администратора,
+// запросы без учетных данных пропускаются, доступ к endpoints проверяет RequireAuth и RequireScope.
+// Неудачные попытки учитываются в failures, после превышения лимита учетные данные с IP адреса не проверяются
+func Auth(apiKeyService service.APIKeyService, username string, password string, failures *ratelimit.Failures) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		rawKey := c.Get(APIKeyHeader)
+		user, pass, basic := basicAuth(c)
+		if rawKey == "" && !basic {
+			return c.Next()
+		}
+
+		err := checkFailures(c, failures)
+		if err != nil {
+			return err
+		}
+
+		if rawKey != "" {
+			var key entity.APIKey
+			key, err = apiKeyService.Authenticate(c.Context(), rawKey)
+			if err != nil {
+				if _, ok := apperror.Is(err, apperror.Unauthorized); ok {
+					return addFailure(c, failures, err)
+				}
+
+				return err
+			}
+
+			c.Locals(apiKeyLocal, key)
+
+			return c.Next()
+		}
+
+		if !equal(user, username) || !equal(pass, password) {
+			return addFailure(c, failures, unauthorized(c))
+		}
+
+		// basic auth администратора дает полный доступ, как ключ с правом admin
+		c.Locals(apiKeyLocal, entity.APIKey{Name: username, Scopes: []string{string(entity.ScopeAdmin)}})
+
+		return c.Next()
+	}
+}
+
+func checkFailures(c *fiber.Ctx, failures *ratelimit.Failures) error {
+	result, err := failures.Check(c.Context(), c.IP())
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	if !result.Allowed {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds(result.Reset)))
+
+		return apperror.TooManyRequests.WithMessage("too many failed authentication attempts, retry in " + strconv.Itoa(seconds(result.Reset)) + "s")
+	}
+
+	return nil
+}
+
+// addFailure учитывает неудачную попытку и возвращает ошибку аутентификации
+func addFailure(c *fiber.Ctx, failures *ratelimit.Failures, authErr error) error {
+	err := failures.Add(c.Context(), c.IP())
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	return authErr
+}
+
+// RequireAuth пропускает только клиентов, прошедших Auth
+func RequireAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if _, ok := c.Locals(apiKeyLocal).(entity.APIKey); !ok {
+			return unauthorized(c)
+		}
+
+		return c.Next()
+	}
+}
+
+// RequireScope пропускает только клиентов, ключ которых имеет право scope
+func RequireScope(scope entity.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key, ok := c.Locals(apiKeyLocal).(entity.APIKey)
+		if !ok {
+			return unauthorized(c)
+		}
+
+		if !key.Allows(scope) {
+			return apperror.Forbidden.WithMessage("api key has no " + string(scope) + " scope")
+		}
+
+		return c.Next()
+	}
+}
+
+func unauthorized(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Basic realm="Restricted"`)
+
+	return apperror.Unauthorized.WithMessage("api key or admin credentials required")
+}
+
+func basicAuth(c *fiber.Ctx) (string, string, bool) {
+	header := c.Get(fiber.HeaderAuthorization)
+	if !strings.HasPrefix(header, "Basic ") {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(header, "Basic "))
+	if err != nil {
+		return "", "", false
+	}
+
+	return strings.Cut(string(decoded), ":")
+}
+
+func equal(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
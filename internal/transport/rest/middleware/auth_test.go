@@ -0,0 +1,161 @@
+package middleware
+
+import (
+	"context"
+	"encoding/base64"
+	"estimate/internal/entity"
+	"estimate/pkg/apperror"
+	"estimate/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// memoryLimiter считает запросы по ключам без окна
+type memoryLimiter struct {
+	counts map[string]int
+}
+
+func (limiter *memoryLimiter) Allow(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	result, _ := limiter.Peek(ctx, key, limit)
+	if result.Allowed {
+		limiter.counts[key]++
+		result.Remaining--
+	}
+
+	return result, nil
+}
+
+func (limiter *memoryLimiter) Peek(_ context.Context, key string, limit ratelimit.Limit) (ratelimit.Result, error) {
+	return ratelimit.Result{
+		Allowed:   limiter.counts[key] < limit.Requests,
+		Limit:     limit.Requests,
+		Remaining: limit.Requests - limiter.counts[key],
+		Reset:     limit.Window,
+	}, nil
+}
+
+// apiKeyService знает один ключ и считает обращения к хранилищу
+type apiKeyService struct {
+	key   entity.APIKey
+	raw   string
+	calls int
+}
+
+func (service *apiKeyService) Issue(context.Context, string, []string) (entity.APIKey, string, error) {
+	return entity.APIKey{}, "", nil
+}
+
+func (service *apiKeyService) Authenticate(_ context.Context, rawKey string) (entity.APIKey, error) {
+	service.calls++
+	if rawKey != service.raw {
+		return entity.APIKey{}, apperror.Unauthorized.WithMessage("invalid api key")
+	}
+
+	return service.key, nil
+}
+
+func (service *apiKeyService) Select(context.Context) ([]entity.APIKey, error) {
+	return nil, nil
+}
+
+func (service *apiKeyService) Revoke(context.Context, int64) error {
+	return nil
+}
+
+func newAuthApp(service *apiKeyService) *fiber.App {
+	failures := ratelimit.NewFailures(&memoryLimiter{counts: map[string]int{}}, ratelimit.Limit{Requests: 3, Window: time.Minute})
+
+	app := fiber.New(fiber.Config{ErrorHandler: Error(zap.NewNop())})
+	app.Use(Auth(service, "admin", "secret", failures))
+	app.Get("/public", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	admin := app.Group("/admin", RequireAuth())
+	admin.Get("/keys", RequireScope(entity.ScopeAdmin), func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusNoContent)
+	})
+	admin.Get("/unscoped", func(c *fiber.Ctx) error {
+		return c.SendStatus(http.StatusNoContent)
+	})
+
+	return app
+}
+
+func request(t *testing.T, app *fiber.App, path string, header string, value string) int {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if header != "" {
+		req.Header.Set(header, value)
+	}
+
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode
+}
+
+func basic(user string, pass string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass))
+}
+
+func TestAuthAdminRequiresPrincipal(t *testing.T) {
+	service := &apiKeyService{raw: "valid", key: entity.APIKey{Scopes: []string{string(entity.ScopeEstimatesRead)}}}
+	app := newAuthApp(service)
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		value  string
+		want   int
+	}{
+		{name: "public without credentials", path: "/public", want: http.StatusNoContent},
+		{name: "admin route without scope check", path: "/admin/unscoped", want: http.StatusUnauthorized},
+		{name: "admin without credentials", path: "/admin/keys", want: http.StatusUnauthorized},
+		{name: "admin with key without scope", path: "/admin/keys", header: APIKeyHeader, value: "valid", want: http.StatusForbidden},
+		{name: "admin basic auth", path: "/admin/keys", header: fiber.HeaderAuthorization, value: basic("admin", "secret"), want: http.StatusNoContent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := request(t, app, test.path, test.header, test.value); got != test.want {
+				t.Errorf("status = %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAuthFailuresLimited(t *testing.T) {
+	service := &apiKeyService{raw: "valid"}
+	app := newAuthApp(service)
+
+	for i := 0; i < 3; i++ {
+		if got := request(t, app, "/public", APIKeyHeader, "guess"); got != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: status = %d, want 401", i+1, got)
+		}
+	}
+
+	if got := request(t, app, "/public", APIKeyHeader, "guess"); got != http.StatusTooManyRequests {
+		t.Errorf("status after failures = %d, want 429", got)
+	}
+
+	if got := request(t, app, "/admin/keys", fiber.HeaderAuthorization, basic("admin", "secret")); got != http.StatusTooManyRequests {
+		t.Errorf("basic auth after failures: status = %d, want 429", got)
+	}
+
+	if service.calls != 3 {
+		t.Errorf("authenticate calls = %d, want 3, blocked attempts must not reach storage", service.calls)
+	}
+
+	if got := request(t, app, "/public", "", ""); got != http.StatusNoContent {
+		t.Errorf("request without credentials: status = %d, want 204", got)
+	}
+}
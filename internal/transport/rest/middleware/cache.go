@@ -4,9 +4,12 @@ import (
 	"errors"
 	"github.com/alejandro-carstens/gocache"
 	"github.com/gofiber/fiber/v2"
+	"strings"
 	"time"
 )
 
+// Cache сохраняет тело ответа вместе с его Content-Type,
+// значение в кеше имеет вид "<content type>\n<тело>"
 func Cache(expiration time.Duration, cache gocache.TaggedCache) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		key := c.OriginalURL()
@@ -19,8 +22,9 @@ func Cache(expiration time.Duration, cache gocache.TaggedCache) fiber.Handler {
 					return err
 				}
 
+				contentType := string(c.Response().Header.ContentType())
 				body := c.Response().Body()
-				err = cache.Put(key, string(body), expiration)
+				err = cache.Put(key, contentType+"\n"+string(body), expiration)
 				if err != nil {
 					return err
 				}
@@ -31,6 +35,14 @@ func Cache(expiration time.Duration, cache gocache.TaggedCache) fiber.Handler {
 			return err
 		}
 
-		return c.Type("json").SendString(result)
+		contentType, body, found := strings.Cut(result, "\n")
+		if !found {
+			// значение сохранено до появления Content-Type в кеше
+			return c.Type("json").SendString(result)
+		}
+
+		c.Set(fiber.HeaderContentType, contentType)
+
+		return c.SendString(body)
 	}
 }
@@ -16,26 +16,54 @@ func Error(logger *zap.Logger) fiber.ErrorHandler {
 
 		var apperr apperror.Error
 		if errors.As(err, &apperr) {
-			switch apperr.Code {
-			case apperror.Internal.Code:
+			if apperr.Code == apperror.Internal.Code {
 				logger.Error("internal error", zap.Error(err))
-
-				c.Status(fiber.StatusInternalServerError)
-			case apperror.NotFound.Code:
-				c.Status(fiber.StatusNotFound)
-			case apperror.AlreadyExists.Code, apperror.BadRequest.Code:
-				c.Status(fiber.StatusBadRequest)
-			case apperror.Unauthorized.Code:
-				c.Status(fiber.StatusUnauthorized)
-			case apperror.Unavailable.Code:
-				c.Status(fiber.StatusOK)
 			}
 
-			return c.JSON(fiber.Map{"error": apperr})
+			return c.Status(errorStatus(err)).JSON(fiber.Map{"error": apperr})
 		}
 
 		logger.Warn("unexpected error", zap.Error(err))
 
-		return c.Status(fiber.StatusTeapot).JSON(fiber.Map{"error": apperror.Unknown.WithError(err)})
+		return c.Status(errorStatus(err)).JSON(fiber.Map{"error": apperror.Unknown.WithError(err)})
+	}
+}
+
+// errorStatus возвращает статус ответа, который ErrorHandler отправит для ошибки
+func errorStatus(err error) int {
+	var e *fiber.Error
+	if errors.As(err, &e) {
+		return e.Code
+	}
+
+	var apperr apperror.Error
+	if !errors.As(err, &apperr) {
+		return fiber.StatusTeapot
 	}
+
+	switch apperr.Code {
+	case apperror.Internal.Code:
+		return fiber.StatusInternalServerError
+	case apperror.NotFound.Code:
+		return fiber.StatusNotFound
+	case apperror.AlreadyExists.Code, apperror.BadRequest.Code:
+		return fiber.StatusBadRequest
+	case apperror.Unauthorized.Code:
+		return fiber.StatusUnauthorized
+	case apperror.Forbidden.Code:
+		return fiber.StatusForbidden
+	case apperror.TooManyRequests.Code:
+		return fiber.StatusTooManyRequests
+	default:
+		return fiber.StatusOK
+	}
+}
+
+// responseStatus возвращает статус ответа, если ошибка обработчика еще не обработана ErrorHandler
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err != nil {
+		return errorStatus(err)
+	}
+
+	return c.Response().StatusCode()
 }
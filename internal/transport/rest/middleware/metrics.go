@@ -1,21 +1,34 @@
 package middleware
 
 import (
+	"estimate/internal/entity"
+	"estimate/internal/service"
 	"github.com/gofiber/fiber/v2"
-	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"time"
 )
 
-func Metrics(client *redis.Client) fiber.Handler {
-	prefix := "metrics:"
+// Metrics учитывает запросы по шаблону маршрута, методу и классу статуса ответа,
+// запросы к незарегистрированным маршрутам учитываются вместе как UnknownRoute,
+// ошибка записи метрики только логируется, чтобы не заменять уже подготовленный ответ
+func Metrics(metricsService service.MetricsService, logger *zap.Logger) fiber.Handler {
+	routes := &routes{}
 
 	return func(c *fiber.Ctx) error {
-		key := c.Path()
+		start := time.Now()
 
-		err := client.Incr(c.Context(), prefix+key).Err()
-		if err != nil {
-			return err
+		err := c.Next()
+
+		recordErr := metricsService.Record(c.Context(), entity.Request{
+			Method:   c.Method(),
+			Route:    routes.template(c),
+			Status:   responseStatus(c, err),
+			Duration: time.Since(start),
+		})
+		if recordErr != nil {
+			logger.Warn("failed to record request metrics", zap.Error(recordErr))
 		}
 
-		return c.Next()
+		return err
 	}
 }
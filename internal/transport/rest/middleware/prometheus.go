@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"estimate/internal/metrics"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+func Prometheus(recorder metrics.Recorder) fiber.Handler {
+	routes := &routes{}
+
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		err := c.Next()
+
+		recorder.ObserveRequest(c.Method(), routes.template(c), responseStatus(c, err), time.Since(start))
+
+		return err
+	}
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"estimate/pkg/apperror"
+	"estimate/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"math"
+	"strconv"
+	"time"
+)
+
+const APIKeyHeader = "X-API-Key"
+
+// RateLimiter ограничивает количество запросов клиента по API ключу, а без ключа - по IP адресу,
+// должен выполняться после Auth, чтобы учитывались только проверенные ключи,
+// header - префикс заголовков с состоянием лимита, у каждого лимита свой, чтобы они не перезаписывали друг друга
+type RateLimiter struct {
+	limiter ratelimit.Limiter
+	scope   string
+	header  string
+	ip      ratelimit.Limit
+	key     ratelimit.Limit
+}
+
+func NewRateLimiter(limiter ratelimit.Limiter, scope string, header string, ip ratelimit.Limit, key ratelimit.Limit) *RateLimiter {
+	return &RateLimiter{
+		limiter: limiter,
+		scope:   scope,
+		header:  header,
+		ip:      ip,
+		key:     key,
+	}
+}
+
+func (rateLimiter *RateLimiter) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		err := rateLimiter.Allow(c)
+		if err != nil {
+			return err
+		}
+
+		return c.Next()
+	}
+}
+
+// Allow учитывает запрос и выставляет заголовки <header>-Limit, -Remaining и -Reset по лимиту клиента,
+// при превышении лимита возвращает apperror.TooManyRequests и заголовок Retry-After
+func (rateLimiter *RateLimiter) Allow(c *fiber.Ctx) error {
+	result, err := ratelimit.AllowClient(c.Context(), rateLimiter.limiter, rateLimiter.scope, c.IP(), c.Get(APIKeyHeader), rateLimiter.ip, rateLimiter.key)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	c.Set(rateLimiter.header+"-Limit", strconv.Itoa(result.Limit))
+	c.Set(rateLimiter.header+"-Remaining", strconv.Itoa(result.Remaining))
+	c.Set(rateLimiter.header+"-Reset", strconv.Itoa(seconds(result.Reset)))
+
+	if !result.Allowed {
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds(result.Reset)))
+
+		return apperror.TooManyRequests.WithMessage("rate limit exceeded, retry in " + strconv.Itoa(seconds(result.Reset)) + "s")
+	}
+
+	return nil
+}
+
+// Take учитывает запрос так же, как Allow, но не изменяет ответ,
+// используется, когда превышение лимита сообщается не статусом ответа, а в его теле
+func (rateLimiter *RateLimiter) Take(c *fiber.Ctx) error {
+	result, err := ratelimit.AllowClient(c.Context(), rateLimiter.limiter, rateLimiter.scope, c.IP(), c.Get(APIKeyHeader), rateLimiter.ip, rateLimiter.key)
+	if err != nil {
+		return apperror.Internal.WithError(err)
+	}
+
+	if !result.Allowed {
+		return apperror.TooManyRequests.WithMessage("rate limit exceeded, retry in " + strconv.Itoa(seconds(result.Reset)) + "s")
+	}
+
+	return nil
+}
+
+func seconds(duration time.Duration) int {
+	return int(math.Ceil(duration.Seconds()))
+}
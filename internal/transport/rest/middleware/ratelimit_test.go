@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"estimate/pkg/apperror"
+	"estimate/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitersKeepOwnHeaders(t *testing.T) {
+	limiter := &memoryLimiter{counts: map[string]int{}}
+	apiLimit := ratelimit.Limit{Requests: 60, Window: time.Minute}
+	liveLimit := ratelimit.Limit{Requests: 5, Window: time.Minute}
+	apiLimiter := NewRateLimiter(limiter, "api", "X-RateLimit", apiLimit, apiLimit)
+	liveLimiter := NewRateLimiter(limiter, "live", "X-RateLimit-Live", liveLimit, liveLimit)
+
+	app := fiber.New()
+	app.Get("/estimate", apiLimiter.Handler(), func(c *fiber.Ctx) error {
+		err := liveLimiter.Allow(c)
+		if err != nil {
+			return err
+		}
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	response, err := app.Test(httptest.NewRequest(http.MethodGet, "/estimate", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"X-RateLimit-Limit":          "60",
+		"X-RateLimit-Remaining":      "59",
+		"X-RateLimit-Live-Limit":     "5",
+		"X-RateLimit-Live-Remaining": "4",
+	}
+	for header, value := range want {
+		if got := response.Header.Get(header); got != value {
+			t.Errorf("%s = %q, want %q", header, got, value)
+		}
+	}
+}
+
+func TestRateLimiterTakeKeepsResponse(t *testing.T) {
+	limit := ratelimit.Limit{Requests: 1, Window: time.Minute}
+	liveLimiter := NewRateLimiter(&memoryLimiter{counts: map[string]int{}}, "live", "X-RateLimit-Live", limit, limit)
+
+	var errs []error
+	app := fiber.New()
+	app.Get("/batch", func(c *fiber.Ctx) error {
+		errs = append(errs, liveLimiter.Take(c), liveLimiter.Take(c))
+
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	response, err := app.Test(httptest.NewRequest(http.MethodGet, "/batch", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if errs[0] != nil {
+		t.Errorf("first take = %v, want allowed", errs[0])
+	}
+
+	if _, ok := apperror.Is(errs[1], apperror.TooManyRequests); !ok {
+		t.Errorf("second take = %v, want too many requests", errs[1])
+	}
+
+	for _, header := range []string{fiber.HeaderRetryAfter, "X-RateLimit-Live-Limit", "X-RateLimit-Live-Remaining"} {
+		if got := response.Header.Get(header); got != "" {
+			t.Errorf("%s = %q, want not set", header, got)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"sync"
+)
+
+// UnknownRoute объединяет запросы к незарегистрированным маршрутам
+const UnknownRoute = "unknown"
+
+// routes определяет шаблон маршрута, которым был обработан запрос,
+// список маршрутов читается при первом запросе, когда все они уже зарегистрированы
+type routes struct {
+	once  sync.Once
+	known map[string]struct{}
+}
+
+func (routes *routes) template(c *fiber.Ctx) string {
+	routes.once.Do(func() {
+		routes.known = make(map[string]struct{})
+		for _, route := range c.App().GetRoutes(true) {
+			routes.known[route.Method+" "+route.Path] = struct{}{}
+		}
+	})
+
+	route := c.Route()
+	if _, ok := routes.known[route.Method+" "+route.Path]; !ok {
+		return UnknownRoute
+	}
+
+	return route.Path
+}
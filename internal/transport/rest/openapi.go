@@ -0,0 +1,266 @@
+package rest
+
+import (
+	"estimate/internal/dto"
+	"estimate/pkg/apperror"
+	"estimate/pkg/openapi"
+	"github.com/gofiber/fiber/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// errorResponse тело ответа, которое отправляет middleware.Error
+type errorResponse struct {
+	Error apperror.Error `json:"error"`
+}
+
+const docsPage = `<!DOCTYPE html>
+<html lang="ru">
+<head>
+	<meta charset="utf-8">
+	<title>Estimate API</title>
+	<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+	<script>
+		SwaggerUIBundle({url: "/openapi.json", dom_id: "#swagger-ui"});
+	</script>
+</body>
+</html>`
+
+// newSpec описывает все маршруты сервера, маршруты без описания попадают в лог при запуске
+func newSpec(publicAPI bool) *openapi.Spec {
+	spec := openapi.New(openapi.Info{
+		Title:       "Estimate API",
+		Version:     "1.0.0",
+		Description: "Сервис проверки доступности сайтов и времени доступа к ним",
+	})
+
+	spec.Override(dto.Duration{}, openapi.Schema{Type: "string", Example: "1.5s"})
+	spec.Override(time.Duration(0), openapi.Schema{Type: "string", Example: "1h"})
+
+	spec.SecurityScheme("apiKey", openapi.SecurityScheme{Type: "apiKey", In: "header", Name: "X-API-Key"})
+	spec.SecurityScheme("basic", openapi.SecurityScheme{Type: "http", Scheme: "basic"})
+
+	authorized := []openapi.SecurityRequirement{{"apiKey": {}}, {"basic": {}}}
+
+	api := authorized
+	if publicAPI {
+		api = nil
+	}
+
+	spec.Add(http.MethodGet, "/metrics", openapi.Operation{
+		Tags:    []string{"service"},
+		Summary: "Метрики Prometheus",
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "Метрики в текстовом формате Prometheus",
+				Content:     map[string]openapi.MediaType{"text/plain": {Schema: &openapi.Schema{Type: "string"}}},
+			},
+			strconv.Itoa(http.StatusUnauthorized): spec.Response(http.StatusText(http.StatusUnauthorized), errorResponse{}),
+			strconv.Itoa(http.StatusForbidden):    spec.Response(http.StatusText(http.StatusForbidden), errorResponse{}),
+		},
+		Security: authorized,
+	})
+
+	spec.Add(http.MethodGet, "/openapi.json", openapi.Operation{
+		Tags:    []string{"service"},
+		Summary: "Спецификация OpenAPI",
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "Спецификация в формате JSON",
+				Content:     map[string]openapi.MediaType{"application/json": {Schema: &openapi.Schema{Type: "object"}}},
+			},
+		},
+	})
+
+	spec.Add(http.MethodGet, "/docs", openapi.Operation{
+		Tags:    []string{"service"},
+		Summary: "Документация API",
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "Страница Swagger UI",
+				Content:     map[string]openapi.MediaType{"text/html": {Schema: &openapi.Schema{Type: "string"}}},
+			},
+		},
+	})
+
+	spec.Add(http.MethodGet, "/status", openapi.Operation{
+		Tags:    []string{"service"},
+		Summary: "Страница статуса сайтов",
+		Responses: map[string]openapi.Response{
+			"200": {
+				Description: "HTML страница с состоянием, временем доступа и временем последней проверки сайтов",
+				Content:     map[string]openapi.MediaType{"text/html": {Schema: &openapi.Schema{Type: "string"}}},
+			},
+		},
+	})
+
+	estimate := operation(spec, "estimate", api)
+
+	spec.Add(http.MethodGet, "/api/v1/estimate", estimate("Время доступа к сайту", dto.GetWebsiteAccessTimeResponse{}, http.StatusBadRequest, http.StatusNotFound, http.StatusTooManyRequests).
+		query(dto.GetWebsiteAccessTimeRequest{}).Operation)
+	spec.Add(http.MethodPost, "/api/v1/estimate/batch", estimate("Время доступа к нескольким сайтам, ошибки возвращаются для каждого адреса", dto.GetBatchResponse{}, http.StatusBadRequest).
+		body(dto.GetBatchRequest{}).Operation)
+	spec.Add(http.MethodGet, "/api/v1/estimate/max", estimate("Сайт с максимальным временем доступа", dto.GetWebsiteWithMaxAccessTimeResponse{}, http.StatusNotFound).Operation)
+	spec.Add(http.MethodGet, "/api/v1/estimate/min", estimate("Сайт с минимальным временем доступа", dto.GetWebsiteWithMinAccessTimeResponse{}, http.StatusNotFound).Operation)
+	spec.Add(http.MethodGet, "/api/v1/estimate/top", estimate("Рейтинг сайтов по времени доступа", []dto.TopWebsiteResponse{}, http.StatusBadRequest).
+		query(dto.GetTopRequest{}).Operation)
+	spec.Add(http.MethodGet, "/api/v1/estimate/history", estimate("История проверок сайта", dto.GetWebsiteHistoryResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		query(dto.GetWebsiteHistoryRequest{}).Operation)
+	spec.Add(http.MethodGet, "/api/v1/estimate/stats", estimate("Статистика времени доступа", dto.GetWebsiteStatsResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		query(dto.GetWebsiteStatsRequest{}).Operation)
+	spec.Add(http.MethodGet, "/api/v1/estimate/redirects", estimate("Цепочка редиректов", dto.GetWebsiteRedirectsResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		query(dto.GetWebsiteRedirectsRequest{}).Operation)
+
+	stream := estimate("Поток результатов проверок", nil, http.StatusBadRequest).
+		query(dto.GetResultStreamRequest{})
+	stream.Parameters = append(stream.Parameters, openapi.Parameter{
+		Name:        "Last-Event-ID",
+		In:          "header",
+		Description: "ID последнего полученного события, поток продолжится с него",
+		Schema:      &openapi.Schema{Type: "string"},
+	})
+	delete(stream.Responses, strconv.Itoa(http.StatusNoContent))
+	stream.Responses[strconv.Itoa(http.StatusOK)] = openapi.Response{
+		Description: "События result в формате Server-Sent Events, данные события в формате JSON",
+		Content:     map[string]openapi.MediaType{"text/event-stream": {Schema: spec.Schema(dto.ResultResponse{})}},
+	}
+	spec.Add(http.MethodGet, "/api/v1/estimate/stream", stream.Operation)
+
+	spec.Add(http.MethodGet, "/api/v1/certificates", operation(spec, "certificates", api)("TLS сертификат сайта", dto.GetCertificateResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		query(dto.GetCertificateRequest{}).Operation)
+
+	spec.Add(http.MethodGet, "/api/v1/incidents", operation(spec, "incidents", api)("Инциденты за период", []dto.IncidentResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		query(dto.GetIncidentsRequest{}).Operation)
+
+	spec.Add(http.MethodGet, "/api/v1/websites", operation(spec, "websites", api)("Страница сайтов с результатами последней проверки", dto.GetWebsitesResponse{}, http.StatusBadRequest).
+		query(dto.GetWebsitesRequest{}).Operation)
+
+	spec.Add(http.MethodGet, "/admin/metrics", operation(spec, "admin", authorized)("Метрики запросов к API", []dto.MetricResponse{}, http.StatusBadRequest).
+		query(dto.GetMetricsRequest{}).Operation)
+
+	websites := operation(spec, "websites", authorized)
+
+	spec.Add(http.MethodPost, "/admin/websites", websites("Добавить сайт", dto.WebsiteResponse{}, http.StatusBadRequest).
+		created().body(dto.CreateWebsiteRequest{}).Operation)
+	spec.Add(http.MethodGet, "/admin/websites", websites("Список сайтов", []dto.WebsiteResponse{}).Operation)
+	spec.Add(http.MethodGet, "/admin/websites/:id", websites("Сайт", dto.WebsiteResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		id().Operation)
+	spec.Add(http.MethodPatch, "/admin/websites/:id", websites("Изменить сайт", dto.WebsiteResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		id().body(dto.PatchWebsiteRequest{}).Operation)
+	spec.Add(http.MethodDelete, "/admin/websites/:id", websites("Удалить сайт", nil, http.StatusBadRequest, http.StatusNotFound).
+		id().Operation)
+
+	spec.Add(http.MethodGet, "/admin/certificates/expiring", websites("Истекающие сертификаты", []dto.ExpiringCertificateResponse{}, http.StatusBadRequest).
+		query(dto.GetExpiringCertificatesRequest{}).Operation)
+
+	channels := operation(spec, "channels", authorized)
+
+	spec.Add(http.MethodPost, "/admin/channels", channels("Добавить канал уведомлений", dto.ChannelResponse{}, http.StatusBadRequest).
+		created().body(dto.CreateChannelRequest{}).Operation)
+	spec.Add(http.MethodGet, "/admin/channels", channels("Список каналов уведомлений", []dto.ChannelResponse{}).Operation)
+	spec.Add(http.MethodGet, "/admin/channels/:id", channels("Канал уведомлений", dto.ChannelResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		id().Operation)
+	spec.Add(http.MethodPatch, "/admin/channels/:id", channels("Изменить канал уведомлений", dto.ChannelResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		id().body(dto.PatchChannelRequest{}).Operation)
+	spec.Add(http.MethodDelete, "/admin/channels/:id", channels("Удалить канал уведомлений", nil, http.StatusBadRequest, http.StatusNotFound).
+		id().Operation)
+	spec.Add(http.MethodPost, "/admin/channels/:id/test", channels("Отправить тестовое уведомление", dto.ChannelTestResponse{}, http.StatusBadRequest, http.StatusNotFound).
+		id().Operation)
+
+	keys := operation(spec, "keys", authorized)
+
+	spec.Add(http.MethodPost, "/admin/keys", keys("Выпустить API ключ", dto.CreateAPIKeyResponse{}, http.StatusBadRequest).
+		created().body(dto.CreateAPIKeyRequest{}).Operation)
+	spec.Add(http.MethodGet, "/admin/keys", keys("Список API ключей", []dto.APIKeyResponse{}).Operation)
+	spec.Add(http.MethodDelete, "/admin/keys/:id", keys("Отозвать API ключ", nil, http.StatusBadRequest, http.StatusNotFound).
+		id().Operation)
+
+	return spec
+}
+
+type operationBuilder struct {
+	openapi.Operation
+	spec *openapi.Spec
+}
+
+// operation возвращает конструктор операций с общим тегом и авторизацией,
+// ответ nil означает пустой ответ 204, ошибки описываются телом middleware.Error
+func operation(spec *openapi.Spec, tag string, security []openapi.SecurityRequirement) func(summary string, response any, errors ...int) *operationBuilder {
+	return func(summary string, response any, errors ...int) *operationBuilder {
+		builder := &operationBuilder{
+			Operation: openapi.Operation{
+				Tags:      []string{tag},
+				Summary:   summary,
+				Responses: make(map[string]openapi.Response),
+				Security:  security,
+			},
+			spec: spec,
+		}
+
+		if response == nil {
+			builder.Responses[strconv.Itoa(http.StatusNoContent)] = openapi.Response{Description: http.StatusText(http.StatusNoContent)}
+		} else {
+			builder.Responses[strconv.Itoa(http.StatusOK)] = spec.Response(http.StatusText(http.StatusOK), response)
+		}
+
+		if security != nil {
+			errors = append(errors, http.StatusUnauthorized, http.StatusForbidden)
+		}
+
+		for _, status := range errors {
+			builder.Responses[strconv.Itoa(status)] = spec.Response(http.StatusText(status), errorResponse{})
+		}
+
+		return builder
+	}
+}
+
+func (builder *operationBuilder) query(request any) *operationBuilder {
+	builder.Parameters = append(builder.Parameters, builder.spec.Query(request)...)
+	return builder
+}
+
+func (builder *operationBuilder) body(request any) *operationBuilder {
+	builder.RequestBody = builder.spec.Body(request)
+	return builder
+}
+
+func (builder *operationBuilder) id() *operationBuilder {
+	builder.Parameters = append(builder.Parameters, openapi.Parameter{
+		Name:     "id",
+		In:       "path",
+		Required: true,
+		Schema:   &openapi.Schema{Type: "integer", Format: "int64"},
+	})
+
+	return builder
+}
+
+func (builder *operationBuilder) created() *operationBuilder {
+	builder.Responses[strconv.Itoa(http.StatusCreated)] = builder.Responses[strconv.Itoa(http.StatusOK)]
+	delete(builder.Responses, strconv.Itoa(http.StatusOK))
+
+	return builder
+}
+
+// undocumentedRoutes возвращает зарегистрированные маршруты, которых нет в спецификации
+func undocumentedRoutes(router *fiber.App, spec *openapi.Spec) []string {
+	var routes []string
+	for _, route := range router.GetRoutes(true) {
+		// fiber регистрирует HEAD для каждого GET маршрута
+		if route.Method == fiber.MethodHead {
+			continue
+		}
+
+		if !spec.Has(route.Method, route.Path) {
+			routes = append(routes, route.Method+" "+route.Path)
+		}
+	}
+
+	return routes
+}
@@ -0,0 +1,78 @@
+package rest
+
+import (
+	"estimate/internal/config"
+	"estimate/internal/metrics"
+	"estimate/internal/transport/rest/handler"
+	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newTestServer собирает сервер так же, как app.Run, при регистрации маршрутов сервисы не вызываются,
+// поэтому вместо них передаются nil
+func newTestServer(publicAPI bool) *Server {
+	limit := ratelimit.Limit{Requests: 1, Window: time.Minute}
+	rateLimiter := middleware.NewRateLimiter(nil, "api", "X-RateLimit", limit, limit)
+
+	return New(
+		config.Server{PublicAPI: publicAPI},
+		nil,
+		nil,
+		rateLimiter,
+		ratelimit.NewFailures(nil, limit),
+		metrics.New(),
+		zap.NewNop(),
+	).Handle(
+		handler.NewEstimateHandler(nil, nil, nil, nil, rateLimiter),
+		handler.NewCertificateHandler(nil, nil),
+		handler.NewIncidentHandler(nil, nil),
+		handler.NewWebsiteHandler(nil, nil),
+		handler.NewStatusHandler(nil, nil, time.Minute),
+		handler.NewAdminHandler(nil, nil, nil, nil),
+	)
+}
+
+func TestRoutesDocumented(t *testing.T) {
+	for _, publicAPI := range []bool{true, false} {
+		server := newTestServer(publicAPI)
+
+		if len(server.router.GetRoutes(true)) == 0 {
+			t.Fatal("no routes registered")
+		}
+
+		for _, route := range undocumentedRoutes(server.router, server.spec) {
+			t.Errorf("public api %t: route %s is missing from openapi spec", publicAPI, route)
+		}
+	}
+}
+
+func TestUndocumentedRouteReported(t *testing.T) {
+	server := newTestServer(true)
+	server.router.Get("/api/v1/undocumented/:id", func(c *fiber.Ctx) error {
+		return nil
+	})
+
+	routes := undocumentedRoutes(server.router, server.spec)
+	if len(routes) != 1 || routes[0] != "GET /api/v1/undocumented/:id" {
+		t.Errorf("undocumented routes = %v, want only GET /api/v1/undocumented/:id", routes)
+	}
+}
+
+func TestMetricsRequireAuth(t *testing.T) {
+	server := newTestServer(true)
+
+	response, err := server.router.Test(httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", response.StatusCode, http.StatusUnauthorized)
+	}
+}
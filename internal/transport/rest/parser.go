@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"reflect"
+	"time"
+)
+
+// parserTypes описывает типы, которые не поддерживаются QueryParser по умолчанию
+var parserTypes = []fiber.ParserType{
+	{
+		Customtype: time.Time{},
+		Converter: func(value string) reflect.Value {
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return reflect.Value{}
+			}
+
+			return reflect.ValueOf(t)
+		},
+	},
+	{
+		Customtype: time.Duration(0),
+		Converter: func(value string) reflect.Value {
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return reflect.Value{}
+			}
+
+			return reflect.ValueOf(d)
+		},
+	},
+}
@@ -2,24 +2,48 @@ package rest
 
 import (
 	"estimate/internal/config"
+	"estimate/internal/entity"
+	"estimate/internal/metrics"
+	"estimate/internal/service"
 	"estimate/internal/transport/rest/handler"
 	"estimate/internal/transport/rest/middleware"
+	"estimate/pkg/openapi"
+	"estimate/pkg/ratelimit"
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/basicauth"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/pprof"
 	"github.com/gofiber/fiber/v2/middleware/recover"
-	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
 )
 
 type Server struct {
-	router      *fiber.App
-	conf        config.Server
-	redisClient *redis.Client
+	router         *fiber.App
+	conf           config.Server
+	metricsService service.MetricsService
+	apiKeyService  service.APIKeyService
+	rateLimiter    *middleware.RateLimiter
+	authFailures   *ratelimit.Failures
+	recorder       metrics.Recorder
+	spec           *openapi.Spec
+	log            *zap.Logger
 }
 
-func New(conf config.Server, redisClient *redis.Client, log *zap.Logger) *Server {
+func New(
+	conf config.Server,
+	metricsService service.MetricsService,
+	apiKeyService service.APIKeyService,
+	rateLimiter *middleware.RateLimiter,
+	authFailures *ratelimit.Failures,
+	recorder metrics.Recorder,
+	log *zap.Logger,
+) *Server {
+	fiber.SetParserDecoder(fiber.ParserConfig{
+		IgnoreUnknownKeys: true,
+		ParserType:        parserTypes,
+		ZeroEmpty:         true,
+	})
+
 	router := fiber.New(fiber.Config{
 		ErrorHandler: middleware.Error(log),
 	})
@@ -28,35 +52,71 @@ func New(conf config.Server, redisClient *redis.Client, log *zap.Logger) *Server
 		pprof.New(),
 		recover.New(),
 		logger.New(),
+		middleware.Prometheus(recorder),
 	)
 
 	return &Server{
-		router:      router,
-		conf:        conf,
-		redisClient: redisClient,
+		router:         router,
+		conf:           conf,
+		metricsService: metricsService,
+		apiKeyService:  apiKeyService,
+		rateLimiter:    rateLimiter,
+		authFailures:   authFailures,
+		recorder:       recorder,
+		spec:           newSpec(conf.PublicAPI),
+		log:            log,
 	}
 }
 
-func (server *Server) Handle(estimateHandler *handler.EstimateHandler, adminHandler *handler.AdminHandler) *Server {
-	auth := basicauth.New(basicauth.Config{
-		Users: map[string]string{
-			server.conf.Admin.Username: server.conf.Admin.Password,
-		},
-	})
+func (server *Server) Handle(
+	estimateHandler *handler.EstimateHandler,
+	certificateHandler *handler.CertificateHandler,
+	incidentHandler *handler.IncidentHandler,
+	websiteHandler *handler.WebsiteHandler,
+	statusHandler *handler.StatusHandler,
+	adminHandler *handler.AdminHandler,
+) *Server {
+	auth := middleware.Auth(server.apiKeyService, server.conf.Admin.Username, server.conf.Admin.Password, server.authFailures)
 
-	api := server.router.Group("/api", middleware.Metrics(server.redisClient))
+	// метрики содержат адреса всех сайтов, поэтому доступны только с правом metrics:read
+	server.router.Get("/metrics", auth, middleware.RequireAuth(), middleware.RequireScope(entity.ScopeMetricsRead),
+		adaptor.HTTPHandler(server.recorder.Handler()))
+	server.router.Get("/openapi.json", server.OpenAPI)
+	server.router.Get("/docs", server.Docs)
+	statusHandler.Register(server.router)
+
+	api := server.router.Group("/api", middleware.Metrics(server.metricsService, server.log), auth, server.rateLimiter.Handler())
 	{
 		v1 := api.Group("/v1")
+		if !server.conf.PublicAPI {
+			v1.Use(middleware.RequireScope(entity.ScopeEstimatesRead))
+		}
 		{
 			estimateHandler.Register(v1.Group("/estimate"))
+			certificateHandler.Register(v1.Group("/certificates"))
+			incidentHandler.Register(v1.Group("/incidents"))
+			websiteHandler.Register(v1.Group("/websites"))
 		}
 	}
 
-	adminHandler.Register(server.router.Group("/admin", auth))
+	adminHandler.Register(server.router.Group("/admin", auth, middleware.RequireAuth()))
+
+	for _, route := range undocumentedRoutes(server.router, server.spec) {
+		server.log.Warn("route is missing from openapi spec", zap.String("route", route))
+	}
 
 	return server
 }
 
+func (server *Server) OpenAPI(c *fiber.Ctx) error {
+	return c.JSON(server.spec.Document())
+}
+
+func (server *Server) Docs(c *fiber.Ctx) error {
+	c.Type("html")
+	return c.SendString(docsPage)
+}
+
 func (server *Server) Listen() error {
 	return server.router.Listen(server.conf.Addr)
 }
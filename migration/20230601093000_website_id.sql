@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN id BIGSERIAL PRIMARY KEY;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website
+    DROP COLUMN id;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE website_check
+(
+    id          BIGSERIAL PRIMARY KEY,
+    website_id  BIGINT      NOT NULL REFERENCES website (id) ON DELETE CASCADE,
+    checked_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    access_time INTERVAL    NOT NULL DEFAULT '0',
+    status_code INTEGER     NOT NULL DEFAULT 0
+);
+
+CREATE INDEX website_check_website_id_checked_at_idx ON website_check (website_id, checked_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE website_check;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN dns_time      INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN connect_time  INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN tls_time      INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN ttfb_time     INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN transfer_time INTERVAL NOT NULL DEFAULT '0';
+
+ALTER TABLE website_check
+    ADD COLUMN dns_time      INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN connect_time  INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN tls_time      INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN ttfb_time     INTERVAL NOT NULL DEFAULT '0',
+    ADD COLUMN transfer_time INTERVAL NOT NULL DEFAULT '0';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website_check
+    DROP COLUMN dns_time,
+    DROP COLUMN connect_time,
+    DROP COLUMN tls_time,
+    DROP COLUMN ttfb_time,
+    DROP COLUMN transfer_time;
+
+ALTER TABLE website
+    DROP COLUMN dns_time,
+    DROP COLUMN connect_time,
+    DROP COLUMN tls_time,
+    DROP COLUMN ttfb_time,
+    DROP COLUMN transfer_time;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN method            TEXT     NOT NULL DEFAULT 'GET',
+    ADD COLUMN headers           JSONB    NOT NULL DEFAULT '{}',
+    ADD COLUMN body              TEXT     NOT NULL DEFAULT '',
+    ADD COLUMN accepted_statuses JSONB    NOT NULL DEFAULT '[{"from": 200, "to": 200}]',
+    ADD COLUMN timeout           INTERVAL NOT NULL DEFAULT '10 seconds',
+    ADD COLUMN follow_redirects  BOOLEAN  NOT NULL DEFAULT TRUE,
+    ADD COLUMN available         BOOLEAN  NOT NULL DEFAULT FALSE;
+
+UPDATE website
+SET available = status_code = 200;
+
+ALTER TABLE website_check
+    ADD COLUMN available BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE website_check
+SET available = status_code = 200;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website_check
+    DROP COLUMN available;
+
+ALTER TABLE website
+    DROP COLUMN method,
+    DROP COLUMN headers,
+    DROP COLUMN body,
+    DROP COLUMN accepted_statuses,
+    DROP COLUMN timeout,
+    DROP COLUMN follow_redirects,
+    DROP COLUMN available;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN assertions JSONB NOT NULL DEFAULT '[]';
+
+ALTER TABLE website_check
+    ADD COLUMN failed_assertion TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website_check
+    DROP COLUMN failed_assertion;
+
+ALTER TABLE website
+    DROP COLUMN assertions;
+-- +goose StatementEnd
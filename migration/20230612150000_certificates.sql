@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN cert_expires_at TIMESTAMPTZ;
+
+ALTER TABLE website_check
+    ADD COLUMN cert_expires_at TIMESTAMPTZ,
+    ADD COLUMN cert_issuer     TEXT   NOT NULL DEFAULT '',
+    ADD COLUMN cert_sans       TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN cert_error      TEXT   NOT NULL DEFAULT '';
+
+CREATE INDEX website_cert_expires_at_idx ON website (cert_expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX website_cert_expires_at_idx;
+
+ALTER TABLE website_check
+    DROP COLUMN cert_expires_at,
+    DROP COLUMN cert_issuer,
+    DROP COLUMN cert_sans,
+    DROP COLUMN cert_error;
+
+ALTER TABLE website
+    DROP COLUMN cert_expires_at;
+-- +goose StatementEnd
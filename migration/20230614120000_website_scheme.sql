@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN scheme         TEXT NOT NULL DEFAULT 'https',
+    ADD COLUMN checked_scheme TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE website_check
+    ADD COLUMN scheme TEXT NOT NULL DEFAULT 'https';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website_check
+    DROP COLUMN scheme;
+
+ALTER TABLE website
+    DROP COLUMN scheme,
+    DROP COLUMN checked_scheme;
+-- +goose StatementEnd
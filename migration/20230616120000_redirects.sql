@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN max_redirects INTEGER NOT NULL DEFAULT 10;
+
+ALTER TABLE website_check
+    ADD COLUMN redirects JSONB NOT NULL DEFAULT '[]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website_check
+    DROP COLUMN redirects;
+
+ALTER TABLE website
+    DROP COLUMN max_redirects;
+-- +goose StatementEnd
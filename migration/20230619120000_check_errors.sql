@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN error_class   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN error_message TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE website_check
+    ADD COLUMN error_class   TEXT NOT NULL DEFAULT '',
+    ADD COLUMN error_message TEXT NOT NULL DEFAULT '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE website_check
+    DROP COLUMN error_class,
+    DROP COLUMN error_message;
+
+ALTER TABLE website
+    DROP COLUMN error_class,
+    DROP COLUMN error_message;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ADD COLUMN state                 TEXT        NOT NULL DEFAULT 'up',
+    ADD COLUMN consecutive_failures  INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN consecutive_successes INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN state_changed_at      TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE TABLE incident
+(
+    id            BIGSERIAL PRIMARY KEY,
+    website_id    BIGINT      NOT NULL REFERENCES website (id) ON DELETE CASCADE,
+    started_at    TIMESTAMPTZ NOT NULL,
+    ended_at      TIMESTAMPTZ,
+    cause_class   TEXT        NOT NULL DEFAULT '',
+    cause_message TEXT        NOT NULL DEFAULT ''
+);
+
+CREATE INDEX incident_website_id_started_at_idx ON incident (website_id, started_at);
+CREATE UNIQUE INDEX incident_website_id_open_idx ON incident (website_id) WHERE ended_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE incident;
+
+ALTER TABLE website
+    DROP COLUMN state,
+    DROP COLUMN consecutive_failures,
+    DROP COLUMN consecutive_successes,
+    DROP COLUMN state_changed_at;
+-- +goose StatementEnd
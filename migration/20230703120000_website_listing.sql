@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX website_url_pattern_idx ON website (url text_pattern_ops);
+CREATE INDEX website_access_time_id_idx ON website (access_time, id);
+CREATE INDEX website_last_check_at_id_idx ON website (last_check_at, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX website_last_check_at_id_idx;
+DROP INDEX website_access_time_id_idx;
+DROP INDEX website_url_pattern_idx;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE website
+    ALTER COLUMN state SET DEFAULT 'unknown';
+
+UPDATE website
+SET state = 'unknown'
+WHERE NOT EXISTS(SELECT 1 FROM website_check WHERE website_check.website_id = website.id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE website
+SET state = 'up'
+WHERE state = 'unknown';
+
+ALTER TABLE website
+    ALTER COLUMN state SET DEFAULT 'up';
+-- +goose StatementEnd
@@ -0,0 +1,295 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: estimate/v1/estimate.proto
+
+package estimatev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	EstimateService_GetEstimate_FullMethodName  = "/estimate.v1.EstimateService/GetEstimate"
+	EstimateService_GetMin_FullMethodName       = "/estimate.v1.EstimateService/GetMin"
+	EstimateService_GetMax_FullMethodName       = "/estimate.v1.EstimateService/GetMax"
+	EstimateService_ListWebsites_FullMethodName = "/estimate.v1.EstimateService/ListWebsites"
+	EstimateService_WatchResults_FullMethodName = "/estimate.v1.EstimateService/WatchResults"
+)
+
+// EstimateServiceClient is the client API for EstimateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type EstimateServiceClient interface {
+	// GetEstimate возвращает время доступа к сайту, неизвестный сайт проверяется в момент запроса
+	GetEstimate(ctx context.Context, in *GetEstimateRequest, opts ...grpc.CallOption) (*Estimate, error)
+	// GetMin возвращает сайт с минимальным временем доступа
+	GetMin(ctx context.Context, in *GetMinRequest, opts ...grpc.CallOption) (*Estimate, error)
+	// GetMax возвращает сайт с максимальным временем доступа
+	GetMax(ctx context.Context, in *GetMaxRequest, opts ...grpc.CallOption) (*Estimate, error)
+	// ListWebsites возвращает все отслеживаемые сайты
+	ListWebsites(ctx context.Context, in *ListWebsitesRequest, opts ...grpc.CallOption) (*ListWebsitesResponse, error)
+	// WatchResults отправляет результат каждой проверки сайта
+	WatchResults(ctx context.Context, in *WatchResultsRequest, opts ...grpc.CallOption) (EstimateService_WatchResultsClient, error)
+}
+
+type estimateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewEstimateServiceClient(cc grpc.ClientConnInterface) EstimateServiceClient {
+	return &estimateServiceClient{cc}
+}
+
+func (c *estimateServiceClient) GetEstimate(ctx context.Context, in *GetEstimateRequest, opts ...grpc.CallOption) (*Estimate, error) {
+	out := new(Estimate)
+	err := c.cc.Invoke(ctx, EstimateService_GetEstimate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *estimateServiceClient) GetMin(ctx context.Context, in *GetMinRequest, opts ...grpc.CallOption) (*Estimate, error) {
+	out := new(Estimate)
+	err := c.cc.Invoke(ctx, EstimateService_GetMin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *estimateServiceClient) GetMax(ctx context.Context, in *GetMaxRequest, opts ...grpc.CallOption) (*Estimate, error) {
+	out := new(Estimate)
+	err := c.cc.Invoke(ctx, EstimateService_GetMax_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *estimateServiceClient) ListWebsites(ctx context.Context, in *ListWebsitesRequest, opts ...grpc.CallOption) (*ListWebsitesResponse, error) {
+	out := new(ListWebsitesResponse)
+	err := c.cc.Invoke(ctx, EstimateService_ListWebsites_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *estimateServiceClient) WatchResults(ctx context.Context, in *WatchResultsRequest, opts ...grpc.CallOption) (EstimateService_WatchResultsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EstimateService_ServiceDesc.Streams[0], EstimateService_WatchResults_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &estimateServiceWatchResultsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EstimateService_WatchResultsClient interface {
+	Recv() (*Result, error)
+	grpc.ClientStream
+}
+
+type estimateServiceWatchResultsClient struct {
+	grpc.ClientStream
+}
+
+func (x *estimateServiceWatchResultsClient) Recv() (*Result, error) {
+	m := new(Result)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// EstimateServiceServer is the server API for EstimateService service.
+// All implementations must embed UnimplementedEstimateServiceServer
+// for forward compatibility
+type EstimateServiceServer interface {
+	// GetEstimate возвращает время доступа к сайту, неизвестный сайт проверяется в момент запроса
+	GetEstimate(context.Context, *GetEstimateRequest) (*Estimate, error)
+	// GetMin возвращает сайт с минимальным временем доступа
+	GetMin(context.Context, *GetMinRequest) (*Estimate, error)
+	// GetMax возвращает сайт с максимальным временем доступа
+	GetMax(context.Context, *GetMaxRequest) (*Estimate, error)
+	// ListWebsites возвращает все отслеживаемые сайты
+	ListWebsites(context.Context, *ListWebsitesRequest) (*ListWebsitesResponse, error)
+	// WatchResults отправляет результат каждой проверки сайта
+	WatchResults(*WatchResultsRequest, EstimateService_WatchResultsServer) error
+	mustEmbedUnimplementedEstimateServiceServer()
+}
+
+// UnimplementedEstimateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedEstimateServiceServer struct {
+}
+
+func (UnimplementedEstimateServiceServer) GetEstimate(context.Context, *GetEstimateRequest) (*Estimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEstimate not implemented")
+}
+func (UnimplementedEstimateServiceServer) GetMin(context.Context, *GetMinRequest) (*Estimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMin not implemented")
+}
+func (UnimplementedEstimateServiceServer) GetMax(context.Context, *GetMaxRequest) (*Estimate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMax not implemented")
+}
+func (UnimplementedEstimateServiceServer) ListWebsites(context.Context, *ListWebsitesRequest) (*ListWebsitesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebsites not implemented")
+}
+func (UnimplementedEstimateServiceServer) WatchResults(*WatchResultsRequest, EstimateService_WatchResultsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResults not implemented")
+}
+func (UnimplementedEstimateServiceServer) mustEmbedUnimplementedEstimateServiceServer() {}
+
+// UnsafeEstimateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to EstimateServiceServer will
+// result in compilation errors.
+type UnsafeEstimateServiceServer interface {
+	mustEmbedUnimplementedEstimateServiceServer()
+}
+
+func RegisterEstimateServiceServer(s grpc.ServiceRegistrar, srv EstimateServiceServer) {
+	s.RegisterService(&EstimateService_ServiceDesc, srv)
+}
+
+func _EstimateService_GetEstimate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEstimateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EstimateServiceServer).GetEstimate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EstimateService_GetEstimate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EstimateServiceServer).GetEstimate(ctx, req.(*GetEstimateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EstimateService_GetMin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EstimateServiceServer).GetMin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EstimateService_GetMin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EstimateServiceServer).GetMin(ctx, req.(*GetMinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EstimateService_GetMax_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMaxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EstimateServiceServer).GetMax(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EstimateService_GetMax_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EstimateServiceServer).GetMax(ctx, req.(*GetMaxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EstimateService_ListWebsites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebsitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EstimateServiceServer).ListWebsites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EstimateService_ListWebsites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EstimateServiceServer).ListWebsites(ctx, req.(*ListWebsitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EstimateService_WatchResults_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchResultsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EstimateServiceServer).WatchResults(m, &estimateServiceWatchResultsServer{stream})
+}
+
+type EstimateService_WatchResultsServer interface {
+	Send(*Result) error
+	grpc.ServerStream
+}
+
+type estimateServiceWatchResultsServer struct {
+	grpc.ServerStream
+}
+
+func (x *estimateServiceWatchResultsServer) Send(m *Result) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// EstimateService_ServiceDesc is the grpc.ServiceDesc for EstimateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var EstimateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "estimate.v1.EstimateService",
+	HandlerType: (*EstimateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEstimate",
+			Handler:    _EstimateService_GetEstimate_Handler,
+		},
+		{
+			MethodName: "GetMin",
+			Handler:    _EstimateService_GetMin_Handler,
+		},
+		{
+			MethodName: "GetMax",
+			Handler:    _EstimateService_GetMax_Handler,
+		},
+		{
+			MethodName: "ListWebsites",
+			Handler:    _EstimateService_ListWebsites_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchResults",
+			Handler:       _EstimateService_WatchResults_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "estimate/v1/estimate.proto",
+}
@@ -0,0 +1,230 @@
+package openapi
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// pathParam параметр пути в формате fiber, например /websites/:id
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// Spec собирает документ OpenAPI, схемы типов строятся по json и query тегам структур
+type Spec struct {
+	document  Document
+	overrides map[reflect.Type]Schema
+}
+
+func New(info Info) *Spec {
+	return &Spec{
+		document: Document{
+			OpenAPI: "3.0.3",
+			Info:    info,
+			Paths:   make(map[string]PathItem),
+			Components: Components{
+				Schemas:         make(map[string]*Schema),
+				SecuritySchemes: make(map[string]SecurityScheme),
+			},
+		},
+		overrides: map[reflect.Type]Schema{
+			reflect.TypeOf(time.Time{}): {Type: "string", Format: "date-time"},
+		},
+	}
+}
+
+// Override задает схему для типа с собственной сериализацией
+func (spec *Spec) Override(value any, schema Schema) {
+	spec.overrides[reflect.TypeOf(value)] = schema
+}
+
+func (spec *Spec) SecurityScheme(name string, scheme SecurityScheme) {
+	spec.document.Components.SecuritySchemes[name] = scheme
+}
+
+// Add добавляет операцию, путь передается в формате fiber,
+// параметры пути, не описанные в операции, добавляются как строки
+func (spec *Spec) Add(method string, path string, operation Operation) {
+	for _, match := range pathParam.FindAllStringSubmatch(path, -1) {
+		if !hasParameter(operation.Parameters, match[1]) {
+			operation.Parameters = append(operation.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Schema:   &Schema{Type: "string"},
+			})
+		}
+	}
+
+	path = Path(path)
+
+	item, ok := spec.document.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		spec.document.Paths[path] = item
+	}
+
+	item[strings.ToLower(method)] = &operation
+}
+
+// Has сообщает, описана ли операция, путь передается в формате fiber
+func (spec *Spec) Has(method string, path string) bool {
+	item, ok := spec.document.Paths[Path(path)]
+	if !ok {
+		return false
+	}
+
+	_, ok = item[strings.ToLower(method)]
+
+	return ok
+}
+
+func (spec *Spec) Document() Document {
+	return spec.document
+}
+
+// Path переводит путь из формата fiber в формат OpenAPI
+func Path(path string) string {
+	return pathParam.ReplaceAllString(path, "{$1}")
+}
+
+// JSON возвращает тело в формате application/json со схемой типа value
+func (spec *Spec) JSON(value any) map[string]MediaType {
+	return map[string]MediaType{
+		"application/json": {Schema: spec.Schema(value)},
+	}
+}
+
+func (spec *Spec) Body(value any) *RequestBody {
+	return &RequestBody{
+		Required: true,
+		Content:  spec.JSON(value),
+	}
+}
+
+func (spec *Spec) Response(description string, value any) Response {
+	return Response{
+		Description: description,
+		Content:     spec.JSON(value),
+	}
+}
+
+// Query возвращает параметры запроса по query тегам структуры
+func (spec *Spec) Query(value any) []Parameter {
+	t := reflect.TypeOf(value)
+
+	var parameters []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name := strings.Split(field.Tag.Get("query"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		parameters = append(parameters, Parameter{
+			Name:   name,
+			In:     "query",
+			Schema: spec.schema(field.Type),
+		})
+	}
+
+	return parameters
+}
+
+// Schema возвращает схему типа value, именованные структуры добавляются в components
+func (spec *Spec) Schema(value any) *Schema {
+	return spec.schema(reflect.TypeOf(value))
+}
+
+func (spec *Spec) schema(t reflect.Type) *Schema {
+	if schema, ok := spec.overrides[t]; ok {
+		return &schema
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := spec.schema(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: spec.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: spec.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return spec.object(t)
+		}
+
+		if _, ok := spec.document.Components.Schemas[t.Name()]; !ok {
+			// схема регистрируется до обхода полей, чтобы рекурсивные типы ссылались на нее
+			spec.document.Components.Schemas[t.Name()] = &Schema{}
+			*spec.document.Components.Schemas[t.Name()] = *spec.object(t)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	default:
+		return &Schema{}
+	}
+}
+
+func (spec *Spec) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	spec.properties(t, schema.Properties)
+
+	return schema
+}
+
+// properties добавляет поля структуры так же, как их сериализует encoding/json,
+// поля встроенных структур без тега поднимаются на уровень выше
+func (spec *Spec) properties(t reflect.Type, properties map[string]*Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			spec.properties(field.Type, properties)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = spec.schema(field.Type)
+	}
+}
+
+func hasParameter(parameters []Parameter, name string) bool {
+	for _, parameter := range parameters {
+		if parameter.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
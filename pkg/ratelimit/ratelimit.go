@@ -0,0 +1,177 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/redis/go-redis/v9"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset время, через которое освободится место в окне
+	Reset time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+	// Peek возвращает состояние окна, не учитывая запрос
+	Peek(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// slidingWindow хранит время каждого запроса в sorted set и учитывает только запросы за последнее окно,
+// возвращает {allowed, remaining, reset в миллисекундах}
+var slidingWindow = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+    redis.call('ZADD', key, now, ARGV[4])
+    redis.call('PEXPIRE', key, window)
+    count = count + 1
+    allowed = 1
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local reset = 0
+if oldest[2] then
+    reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, limit - count, reset}
+`)
+
+// peekWindow очищает устаревшие запросы и возвращает {allowed, remaining, reset} без записи нового запроса
+var peekWindow = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+    allowed = 1
+end
+
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local reset = 0
+if oldest[2] then
+    reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, math.max(limit - count, 0), reset}
+`)
+
+type limiter struct {
+	client *redis.Client
+	prefix string
+}
+
+func New(client *redis.Client) Limiter {
+	return &limiter{
+		client: client,
+		prefix: "ratelimit:",
+	}
+}
+
+// Allow учитывает запрос в скользящем окне и сообщает, укладывается ли он в лимит
+func (limiter *limiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	now := time.Now().UnixMilli()
+	member := strconv.FormatInt(now, 10) + "-" + strconv.FormatInt(rand.Int63(), 36)
+
+	values, err := slidingWindow.Run(ctx, limiter.client, []string{limiter.prefix + key},
+		now,
+		limit.Window.Milliseconds(),
+		limit.Requests,
+		member,
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(values, limit), nil
+}
+
+func (limiter *limiter) Peek(ctx context.Context, key string, limit Limit) (Result, error) {
+	values, err := peekWindow.Run(ctx, limiter.client, []string{limiter.prefix + key},
+		time.Now().UnixMilli(),
+		limit.Window.Milliseconds(),
+		limit.Requests,
+	).Int64Slice()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(values, limit), nil
+}
+
+func newResult(values []int64, limit Limit) Result {
+	return Result{
+		Allowed:   values[0] == 1,
+		Limit:     limit.Requests,
+		Remaining: int(values[1]),
+		Reset:     time.Duration(values[2]) * time.Millisecond,
+	}
+}
+
+// Failures ограничивает количество неудачных попыток аутентификации с одного IP адреса,
+// чтобы перебор ключей и пароля не нагружал базу
+type Failures struct {
+	limiter Limiter
+	limit   Limit
+}
+
+func NewFailures(limiter Limiter, limit Limit) *Failures {
+	return &Failures{
+		limiter: limiter,
+		limit:   limit,
+	}
+}
+
+// Check сообщает, можно ли проверять учетные данные клиента, сама проверка в лимите не учитывается
+func (failures *Failures) Check(ctx context.Context, ip string) (Result, error) {
+	return failures.limiter.Peek(ctx, "auth_failures:ip:"+ip, failures.limit)
+}
+
+// Add учитывает неудачную попытку аутентификации
+func (failures *Failures) Add(ctx context.Context, ip string) error {
+	_, err := failures.limiter.Allow(ctx, "auth_failures:ip:"+ip, failures.limit)
+
+	return err
+}
+
+// AllowClient учитывает запрос клиента по API ключу, а без ключа - по IP адресу,
+// apiKey должен быть уже проверен, иначе случайные ключи обходят лимит по IP
+func AllowClient(ctx context.Context, limiter Limiter, scope string, ip string, apiKey string, ipLimit Limit, keyLimit Limit) (Result, error) {
+	if apiKey != "" {
+		return limiter.Allow(ctx, scope+":key:"+hashKey(apiKey), keyLimit)
+	}
+
+	return limiter.Allow(ctx, scope+":ip:"+ip, ipLimit)
+}
+
+// hashKey не дает хранить API ключи в Redis в открытом виде
+func hashKey(apiKey string) string {
+	sum := sha256.Sum256([]byte(apiKey))
+
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// countingLimiter считает запросы по ключам без окна
+type countingLimiter struct {
+	counts map[string]int
+}
+
+func (limiter *countingLimiter) Allow(_ context.Context, key string, limit Limit) (Result, error) {
+	limiter.counts[key]++
+
+	remaining := limit.Requests - limiter.counts[key]
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return Result{
+		Allowed:   limiter.counts[key] <= limit.Requests,
+		Limit:     limit.Requests,
+		Remaining: remaining,
+	}, nil
+}
+
+func (limiter *countingLimiter) Peek(_ context.Context, key string, limit Limit) (Result, error) {
+	return Result{
+		Allowed: limiter.counts[key] < limit.Requests,
+		Limit:   limit.Requests,
+	}, nil
+}
+
+func TestAllowClientKeyNotLimitedByIP(t *testing.T) {
+	limiter := &countingLimiter{counts: map[string]int{}}
+	ipLimit := Limit{Requests: 2, Window: time.Minute}
+	keyLimit := Limit{Requests: 5, Window: time.Minute}
+
+	for i := 0; i < 5; i++ {
+		result, err := AllowClient(context.Background(), limiter, "api", "10.0.0.1", "key", ipLimit, keyLimit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !result.Allowed {
+			t.Fatalf("request %d with api key must be allowed up to key limit", i+1)
+		}
+
+		if result.Limit != keyLimit.Requests {
+			t.Errorf("limit = %d, want key limit %d", result.Limit, keyLimit.Requests)
+		}
+	}
+
+	result, _ := AllowClient(context.Background(), limiter, "api", "10.0.0.1", "key", ipLimit, keyLimit)
+	if result.Allowed {
+		t.Error("request over key limit must be rejected")
+	}
+
+	result, _ = AllowClient(context.Background(), limiter, "api", "10.0.0.1", "", ipLimit, keyLimit)
+	if !result.Allowed || result.Limit != ipLimit.Requests {
+		t.Errorf("request without key must use untouched ip limit, got %+v", result)
+	}
+
+	if _, ok := limiter.counts["api:key:key"]; ok {
+		t.Error("api key must not be stored in plain text")
+	}
+}
+
+func TestFailures(t *testing.T) {
+	limiter := &countingLimiter{counts: map[string]int{}}
+	failures := NewFailures(limiter, Limit{Requests: 2, Window: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		result, err := failures.Check(context.Background(), "10.0.0.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Allowed != (i < 2) {
+			t.Errorf("check after %d failures: allowed = %t", i, result.Allowed)
+		}
+
+		err = failures.Add(context.Background(), "10.0.0.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, _ := failures.Check(context.Background(), "10.0.0.2")
+	if !result.Allowed {
+		t.Error("failures of another ip must not be counted")
+	}
+}
@@ -0,0 +1,71 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Config struct {
+	Attempts        int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+type stopError struct {
+	err error
+}
+
+func (err stopError) Error() string {
+	return err.err.Error()
+}
+
+func (err stopError) Unwrap() error {
+	return err.err
+}
+
+// Stop помечает ошибку как окончательную, после нее повторные попытки не выполняются
+func Stop(err error) error {
+	return stopError{err: err}
+}
+
+// Do выполняет fn, пока она не завершится без ошибки или не закончатся попытки,
+// интервал между попытками удваивается, но не превышает MaxInterval
+func Do(ctx context.Context, config Config, fn func(ctx context.Context) error) error {
+	if config.Attempts < 1 {
+		config.Attempts = 1
+	}
+
+	interval := config.InitialInterval
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		var stop stopError
+		if errors.As(err, &stop) {
+			return stop.err
+		}
+
+		if attempt >= config.Attempts {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		}
+
+		interval *= 2
+		if config.MaxInterval > 0 && interval > config.MaxInterval {
+			interval = config.MaxInterval
+		}
+	}
+}